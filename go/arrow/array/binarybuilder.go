@@ -82,6 +82,7 @@ func (b *BinaryBuilder) AppendString(v string) {
 }
 
 func (b *BinaryBuilder) AppendNull() {
+	b.builder.checkNull()
 	b.Reserve(1)
 	b.appendNextOffset()
 	b.UnsafeAppendBoolToBitmap(false)
@@ -91,6 +92,7 @@ func (b *BinaryBuilder) AppendNull() {
 // in v are valid (not null). The valid slice must either be empty or be equal in length to v. If empty,
 // all values in v are appended and considered valid.
 func (b *BinaryBuilder) AppendValues(v [][]byte, valid []bool) {
+	b.builder.checkValid(valid)
 	if len(v) != len(valid) && len(valid) != 0 {
 		panic("len(v) != len(valid) && len(valid) != 0")
 	}
@@ -112,6 +114,7 @@ func (b *BinaryBuilder) AppendValues(v [][]byte, valid []bool) {
 // in v are valid (not null). The valid slice must either be empty or be equal in length to v. If empty,
 // all values in v are appended and considered valid.
 func (b *BinaryBuilder) AppendStringValues(v []string, valid []bool) {
+	b.builder.checkValid(valid)
 	if len(v) != len(valid) && len(valid) != 0 {
 		panic("len(v) != len(valid) && len(valid) != 0")
 	}
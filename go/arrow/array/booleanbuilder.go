@@ -66,6 +66,7 @@ func (b *BooleanBuilder) AppendByte(v byte) {
 }
 
 func (b *BooleanBuilder) AppendNull() {
+	b.builder.checkNull()
 	b.Reserve(1)
 	b.UnsafeAppendBoolToBitmap(false)
 }
@@ -81,6 +82,7 @@ func (b *BooleanBuilder) UnsafeAppend(v bool) {
 }
 
 func (b *BooleanBuilder) AppendValues(v []bool, valid []bool) {
+	b.builder.checkValid(valid)
 	if len(v) != len(valid) && len(valid) != 0 {
 		panic("len(v) != len(valid) && len(valid) != 0")
 	}
@@ -66,6 +66,7 @@ type Builder interface {
 
 	init(capacity int)
 	resize(newBits int, init func(int))
+	setNonNullable(field *arrow.Field) *arrow.Field
 }
 
 // builder provides common functionality for managing the validity bitmap (nulls) when building arrays.
@@ -76,6 +77,10 @@ type builder struct {
 	nulls      int
 	length     int
 	capacity   int
+
+	// nonNullable is the non-nullable field whose values are being built,
+	// nil if the builder accepts null values.
+	nonNullable *arrow.Field
 }
 
 // Retain increases the reference count by 1.
@@ -93,6 +98,36 @@ func (b *builder) Cap() int { return b.capacity }
 // NullN returns the number of null values in the array builder.
 func (b *builder) NullN() int { return b.nulls }
 
+// setNonNullable marks the builder as building the values of the provided
+// non-nullable field: appending null values to the builder then panics.
+// A nil field makes the builder accept null values again.
+// setNonNullable returns the previous non-nullable field of the builder.
+func (b *builder) setNonNullable(field *arrow.Field) *arrow.Field {
+	old := b.nonNullable
+	b.nonNullable = field
+	return old
+}
+
+// checkNull panics if the builder does not accept null values.
+func (b *builder) checkNull() {
+	if b.nonNullable != nil {
+		panic(fmt.Errorf("arrow/array: null value appended to non-nullable field %q", b.nonNullable.Name))
+	}
+}
+
+// checkValid panics if valid holds a null entry and the builder does not
+// accept null values.
+func (b *builder) checkValid(valid []bool) {
+	if b.nonNullable == nil {
+		return
+	}
+	for _, v := range valid {
+		if !v {
+			b.checkNull()
+		}
+	}
+}
+
 func (b *builder) init(capacity int) {
 	toAlloc := bitutil.CeilByte(capacity) / 8
 	b.nullBitmap = memory.NewResizableBuffer(b.mem)
@@ -200,6 +235,9 @@ func (b *builder) unsafeSetValid(length int) {
 }
 
 func (b *builder) UnsafeAppendBoolToBitmap(isValid bool) {
+	if !isValid {
+		b.checkNull()
+	}
 	if isValid {
 		bitutil.SetBit(b.nullBitmap.Bytes(), b.length)
 	} else {
@@ -244,8 +282,12 @@ func newBuilder(mem memory.Allocator, dtype arrow.DataType) Builder {
 		typ := dtype.(*arrow.FixedSizeBinaryType)
 		return NewFixedSizeBinaryBuilder(mem, typ)
 	case arrow.DATE32:
+		return NewDate32Builder(mem)
 	case arrow.DATE64:
+		return NewDate64Builder(mem)
 	case arrow.TIMESTAMP:
+		typ := dtype.(*arrow.TimestampType)
+		return NewTimestampBuilder(mem, typ)
 	case arrow.TIME32:
 		typ := dtype.(*arrow.Time32Type)
 		return NewTime32Builder(mem, typ)
@@ -254,6 +296,8 @@ func newBuilder(mem memory.Allocator, dtype arrow.DataType) Builder {
 		return NewTime64Builder(mem, typ)
 	case arrow.INTERVAL:
 	case arrow.DECIMAL:
+		typ := dtype.(*arrow.Decimal128Type)
+		return NewDecimal128Builder(mem, typ)
 	case arrow.LIST:
 		typ := dtype.(*arrow.ListType)
 		return NewListBuilder(mem, typ.Elem())
@@ -268,6 +312,8 @@ func newBuilder(mem memory.Allocator, dtype arrow.DataType) Builder {
 		typ := dtype.(*arrow.FixedSizeListType)
 		return NewFixedSizeListBuilder(mem, typ.Len(), typ.Elem())
 	case arrow.DURATION:
+		typ := dtype.(*arrow.DurationType)
+		return NewDurationBuilder(mem, typ)
 	}
 	panic(fmt.Errorf("arrow/array: unsupported builder for %T", dtype))
 }
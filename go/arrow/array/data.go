@@ -33,6 +33,7 @@ type Data struct {
 	length    int
 	buffers   []*memory.Buffer // TODO(sgc): should this be an interface?
 	childData []*Data          // TODO(sgc): managed by ListArray, StructArray and UnionArray types
+	stats     atomic.Value     // *Statistics, computed lazily by ArrayStatistics
 }
 
 func NewData(dtype arrow.DataType, length int, buffers []*memory.Buffer, childData []*Data, nulls, offset int) *Data {
@@ -91,7 +92,16 @@ func (d *Data) Len() int                  { return d.length }
 func (d *Data) Offset() int               { return d.offset }
 func (d *Data) Buffers() []*memory.Buffer { return d.buffers }
 
+// SetStatistics attaches precomputed statistics to the data, replacing
+// any statistics previously attached or computed.
+// The statistics must describe the values of the data: they are returned
+// as-is by ArrayStatistics.
+func (d *Data) SetStatistics(stats Statistics) {
+	d.stats.Store(&stats)
+}
+
 // NewSliceData returns a new slice that shares backing data with the input.
+// Statistics attached to the input are not carried over to the slice.
 // The returned Data slice starts at i and extends j-i elements, such as:
 //    slice := data[i:j]
 // The returned value must be Release'd after use.
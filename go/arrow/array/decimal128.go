@@ -132,11 +132,15 @@ func (b *Decimal128Builder) UnsafeAppend(v decimal128.Num) {
 }
 
 func (b *Decimal128Builder) AppendNull() {
+	b.builder.checkNull()
 	b.Reserve(1)
 	b.UnsafeAppendBoolToBitmap(false)
 }
 
 func (b *Decimal128Builder) UnsafeAppendBoolToBitmap(isValid bool) {
+	if !isValid {
+		b.builder.checkNull()
+	}
 	if isValid {
 		bitutil.SetBit(b.nullBitmap.Bytes(), b.length)
 	} else {
@@ -149,6 +153,7 @@ func (b *Decimal128Builder) UnsafeAppendBoolToBitmap(isValid bool) {
 // in v are valid (not null). The valid slice must either be empty or be equal in length to v. If empty,
 // all values in v are appended and considered valid.
 func (b *Decimal128Builder) AppendValues(v []decimal128.Num, valid []bool) {
+	b.builder.checkValid(valid)
 	if len(v) != len(valid) && len(valid) != 0 {
 		panic("len(v) != len(valid) && len(valid) != 0")
 	}
@@ -147,16 +147,21 @@ func (b *FixedSizeListBuilder) Release() {
 }
 
 func (b *FixedSizeListBuilder) Append(v bool) {
+	if !v {
+		b.builder.checkNull()
+	}
 	b.Reserve(1)
 	b.unsafeAppendBoolToBitmap(v)
 }
 
 func (b *FixedSizeListBuilder) AppendNull() {
+	b.builder.checkNull()
 	b.Reserve(1)
 	b.unsafeAppendBoolToBitmap(false)
 }
 
 func (b *FixedSizeListBuilder) AppendValues(valid []bool) {
+	b.builder.checkValid(valid)
 	b.Reserve(len(valid))
 	b.builder.unsafeAppendBoolsToBitmap(valid, len(valid))
 }
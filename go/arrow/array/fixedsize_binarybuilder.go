@@ -72,6 +72,7 @@ func (b *FixedSizeBinaryBuilder) Append(v []byte) {
 }
 
 func (b *FixedSizeBinaryBuilder) AppendNull() {
+	b.builder.checkNull()
 	b.Reserve(1)
 	b.values.Advance(b.dtype.ByteWidth)
 	b.UnsafeAppendBoolToBitmap(false)
@@ -81,6 +82,7 @@ func (b *FixedSizeBinaryBuilder) AppendNull() {
 // in v are valid (not null). The valid slice must either be empty or be equal in length to v. If empty,
 // all values in v are appended and considered valid.
 func (b *FixedSizeBinaryBuilder) AppendValues(v [][]byte, valid []bool) {
+	b.builder.checkValid(valid)
 	if len(v) != len(valid) && len(valid) != 0 {
 		panic("len(v) != len(valid) && len(valid) != 0")
 	}
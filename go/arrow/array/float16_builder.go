@@ -67,11 +67,15 @@ func (b *Float16Builder) UnsafeAppend(v float16.Num) {
 }
 
 func (b *Float16Builder) AppendNull() {
+	b.builder.checkNull()
 	b.Reserve(1)
 	b.UnsafeAppendBoolToBitmap(false)
 }
 
 func (b *Float16Builder) UnsafeAppendBoolToBitmap(isValid bool) {
+	if !isValid {
+		b.builder.checkNull()
+	}
 	if isValid {
 		bitutil.SetBit(b.nullBitmap.Bytes(), b.length)
 	} else {
@@ -84,6 +88,7 @@ func (b *Float16Builder) UnsafeAppendBoolToBitmap(isValid bool) {
 // in v are valid (not null). The valid slice must either be empty or be equal in length to v. If empty,
 // all values in v are appended and considered valid.
 func (b *Float16Builder) AppendValues(v []float16.Num, valid []bool) {
+	b.builder.checkValid(valid)
 	if len(v) != len(valid) && len(valid) != 0 {
 		panic("len(v) != len(valid) && len(valid) != 0")
 	}
@@ -131,6 +131,7 @@ func (b *MonthIntervalBuilder) Append(v arrow.MonthInterval) {
 }
 
 func (b *MonthIntervalBuilder) AppendNull() {
+	b.builder.checkNull()
 	b.Reserve(1)
 	b.UnsafeAppendBoolToBitmap(false)
 }
@@ -142,6 +143,9 @@ func (b *MonthIntervalBuilder) UnsafeAppend(v arrow.MonthInterval) {
 }
 
 func (b *MonthIntervalBuilder) UnsafeAppendBoolToBitmap(isValid bool) {
+	if !isValid {
+		b.builder.checkNull()
+	}
 	if isValid {
 		bitutil.SetBit(b.nullBitmap.Bytes(), b.length)
 	} else {
@@ -154,6 +158,7 @@ func (b *MonthIntervalBuilder) UnsafeAppendBoolToBitmap(isValid bool) {
 // in v are valid (not null). The valid slice must either be empty or be equal in length to v. If empty,
 // all values in v are appended and considered valid.
 func (b *MonthIntervalBuilder) AppendValues(v []arrow.MonthInterval, valid []bool) {
+	b.builder.checkValid(valid)
 	if len(v) != len(valid) && len(valid) != 0 {
 		panic("len(v) != len(valid) && len(valid) != 0")
 	}
@@ -324,6 +329,7 @@ func (b *DayTimeIntervalBuilder) Append(v arrow.DayTimeInterval) {
 }
 
 func (b *DayTimeIntervalBuilder) AppendNull() {
+	b.builder.checkNull()
 	b.Reserve(1)
 	b.UnsafeAppendBoolToBitmap(false)
 }
@@ -335,6 +341,9 @@ func (b *DayTimeIntervalBuilder) UnsafeAppend(v arrow.DayTimeInterval) {
 }
 
 func (b *DayTimeIntervalBuilder) UnsafeAppendBoolToBitmap(isValid bool) {
+	if !isValid {
+		b.builder.checkNull()
+	}
 	if isValid {
 		bitutil.SetBit(b.nullBitmap.Bytes(), b.length)
 	} else {
@@ -347,6 +356,7 @@ func (b *DayTimeIntervalBuilder) UnsafeAppendBoolToBitmap(isValid bool) {
 // in v are valid (not null). The valid slice must either be empty or be equal in length to v. If empty,
 // all values in v are appended and considered valid.
 func (b *DayTimeIntervalBuilder) AppendValues(v []arrow.DayTimeInterval, valid []bool) {
+	b.builder.checkValid(valid)
 	if len(v) != len(valid) && len(valid) != 0 {
 		panic("len(v) != len(valid) && len(valid) != 0")
 	}
@@ -153,18 +153,23 @@ func (b *ListBuilder) appendNextOffset() {
 }
 
 func (b *ListBuilder) Append(v bool) {
+	if !v {
+		b.builder.checkNull()
+	}
 	b.Reserve(1)
 	b.unsafeAppendBoolToBitmap(v)
 	b.appendNextOffset()
 }
 
 func (b *ListBuilder) AppendNull() {
+	b.builder.checkNull()
 	b.Reserve(1)
 	b.unsafeAppendBoolToBitmap(false)
 	b.appendNextOffset()
 }
 
 func (b *ListBuilder) AppendValues(offsets []int32, valid []bool) {
+	b.builder.checkValid(valid)
 	b.Reserve(len(valid))
 	b.offsets.AppendValues(offsets, nil)
 	b.builder.unsafeAppendBoolsToBitmap(valid, len(valid))
@@ -96,6 +96,7 @@ func (b *NullBuilder) Release() {
 }
 
 func (b *NullBuilder) AppendNull() {
+	b.builder.checkNull()
 	b.builder.length++
 	b.builder.nulls++
 }
@@ -82,6 +82,23 @@ func arrayEqualInt64(left, right *Int64) bool {
 	return true
 }
 
+func minMaxInt64(a *Int64) (min, max int64, ok bool) {
+	for i, v := range a.values {
+		if a.IsNull(i) {
+			continue
+		}
+		switch {
+		case !ok:
+			min, max, ok = v, v, true
+		case v < min:
+			min = v
+		case v > max:
+			max = v
+		}
+	}
+	return min, max, ok
+}
+
 // A type which represents an immutable sequence of uint64 values.
 type Uint64 struct {
 	array
@@ -139,6 +156,23 @@ func arrayEqualUint64(left, right *Uint64) bool {
 	return true
 }
 
+func minMaxUint64(a *Uint64) (min, max uint64, ok bool) {
+	for i, v := range a.values {
+		if a.IsNull(i) {
+			continue
+		}
+		switch {
+		case !ok:
+			min, max, ok = v, v, true
+		case v < min:
+			min = v
+		case v > max:
+			max = v
+		}
+	}
+	return min, max, ok
+}
+
 // A type which represents an immutable sequence of float64 values.
 type Float64 struct {
 	array
@@ -196,6 +230,23 @@ func arrayEqualFloat64(left, right *Float64) bool {
 	return true
 }
 
+func minMaxFloat64(a *Float64) (min, max float64, ok bool) {
+	for i, v := range a.values {
+		if a.IsNull(i) || v != v {
+			continue
+		}
+		switch {
+		case !ok:
+			min, max, ok = v, v, true
+		case v < min:
+			min = v
+		case v > max:
+			max = v
+		}
+	}
+	return min, max, ok
+}
+
 // A type which represents an immutable sequence of int32 values.
 type Int32 struct {
 	array
@@ -253,6 +304,23 @@ func arrayEqualInt32(left, right *Int32) bool {
 	return true
 }
 
+func minMaxInt32(a *Int32) (min, max int32, ok bool) {
+	for i, v := range a.values {
+		if a.IsNull(i) {
+			continue
+		}
+		switch {
+		case !ok:
+			min, max, ok = v, v, true
+		case v < min:
+			min = v
+		case v > max:
+			max = v
+		}
+	}
+	return min, max, ok
+}
+
 // A type which represents an immutable sequence of uint32 values.
 type Uint32 struct {
 	array
@@ -310,6 +378,23 @@ func arrayEqualUint32(left, right *Uint32) bool {
 	return true
 }
 
+func minMaxUint32(a *Uint32) (min, max uint32, ok bool) {
+	for i, v := range a.values {
+		if a.IsNull(i) {
+			continue
+		}
+		switch {
+		case !ok:
+			min, max, ok = v, v, true
+		case v < min:
+			min = v
+		case v > max:
+			max = v
+		}
+	}
+	return min, max, ok
+}
+
 // A type which represents an immutable sequence of float32 values.
 type Float32 struct {
 	array
@@ -367,6 +452,23 @@ func arrayEqualFloat32(left, right *Float32) bool {
 	return true
 }
 
+func minMaxFloat32(a *Float32) (min, max float32, ok bool) {
+	for i, v := range a.values {
+		if a.IsNull(i) || v != v {
+			continue
+		}
+		switch {
+		case !ok:
+			min, max, ok = v, v, true
+		case v < min:
+			min = v
+		case v > max:
+			max = v
+		}
+	}
+	return min, max, ok
+}
+
 // A type which represents an immutable sequence of int16 values.
 type Int16 struct {
 	array
@@ -424,6 +526,23 @@ func arrayEqualInt16(left, right *Int16) bool {
 	return true
 }
 
+func minMaxInt16(a *Int16) (min, max int16, ok bool) {
+	for i, v := range a.values {
+		if a.IsNull(i) {
+			continue
+		}
+		switch {
+		case !ok:
+			min, max, ok = v, v, true
+		case v < min:
+			min = v
+		case v > max:
+			max = v
+		}
+	}
+	return min, max, ok
+}
+
 // A type which represents an immutable sequence of uint16 values.
 type Uint16 struct {
 	array
@@ -481,6 +600,23 @@ func arrayEqualUint16(left, right *Uint16) bool {
 	return true
 }
 
+func minMaxUint16(a *Uint16) (min, max uint16, ok bool) {
+	for i, v := range a.values {
+		if a.IsNull(i) {
+			continue
+		}
+		switch {
+		case !ok:
+			min, max, ok = v, v, true
+		case v < min:
+			min = v
+		case v > max:
+			max = v
+		}
+	}
+	return min, max, ok
+}
+
 // A type which represents an immutable sequence of int8 values.
 type Int8 struct {
 	array
@@ -538,6 +674,23 @@ func arrayEqualInt8(left, right *Int8) bool {
 	return true
 }
 
+func minMaxInt8(a *Int8) (min, max int8, ok bool) {
+	for i, v := range a.values {
+		if a.IsNull(i) {
+			continue
+		}
+		switch {
+		case !ok:
+			min, max, ok = v, v, true
+		case v < min:
+			min = v
+		case v > max:
+			max = v
+		}
+	}
+	return min, max, ok
+}
+
 // A type which represents an immutable sequence of uint8 values.
 type Uint8 struct {
 	array
@@ -595,6 +748,23 @@ func arrayEqualUint8(left, right *Uint8) bool {
 	return true
 }
 
+func minMaxUint8(a *Uint8) (min, max uint8, ok bool) {
+	for i, v := range a.values {
+		if a.IsNull(i) {
+			continue
+		}
+		switch {
+		case !ok:
+			min, max, ok = v, v, true
+		case v < min:
+			min = v
+		case v > max:
+			max = v
+		}
+	}
+	return min, max, ok
+}
+
 // A type which represents an immutable sequence of arrow.Timestamp values.
 type Timestamp struct {
 	array
@@ -652,6 +822,23 @@ func arrayEqualTimestamp(left, right *Timestamp) bool {
 	return true
 }
 
+func minMaxTimestamp(a *Timestamp) (min, max arrow.Timestamp, ok bool) {
+	for i, v := range a.values {
+		if a.IsNull(i) {
+			continue
+		}
+		switch {
+		case !ok:
+			min, max, ok = v, v, true
+		case v < min:
+			min = v
+		case v > max:
+			max = v
+		}
+	}
+	return min, max, ok
+}
+
 // A type which represents an immutable sequence of arrow.Time32 values.
 type Time32 struct {
 	array
@@ -709,6 +896,23 @@ func arrayEqualTime32(left, right *Time32) bool {
 	return true
 }
 
+func minMaxTime32(a *Time32) (min, max arrow.Time32, ok bool) {
+	for i, v := range a.values {
+		if a.IsNull(i) {
+			continue
+		}
+		switch {
+		case !ok:
+			min, max, ok = v, v, true
+		case v < min:
+			min = v
+		case v > max:
+			max = v
+		}
+	}
+	return min, max, ok
+}
+
 // A type which represents an immutable sequence of arrow.Time64 values.
 type Time64 struct {
 	array
@@ -766,6 +970,23 @@ func arrayEqualTime64(left, right *Time64) bool {
 	return true
 }
 
+func minMaxTime64(a *Time64) (min, max arrow.Time64, ok bool) {
+	for i, v := range a.values {
+		if a.IsNull(i) {
+			continue
+		}
+		switch {
+		case !ok:
+			min, max, ok = v, v, true
+		case v < min:
+			min = v
+		case v > max:
+			max = v
+		}
+	}
+	return min, max, ok
+}
+
 // A type which represents an immutable sequence of arrow.Date32 values.
 type Date32 struct {
 	array
@@ -823,6 +1044,23 @@ func arrayEqualDate32(left, right *Date32) bool {
 	return true
 }
 
+func minMaxDate32(a *Date32) (min, max arrow.Date32, ok bool) {
+	for i, v := range a.values {
+		if a.IsNull(i) {
+			continue
+		}
+		switch {
+		case !ok:
+			min, max, ok = v, v, true
+		case v < min:
+			min = v
+		case v > max:
+			max = v
+		}
+	}
+	return min, max, ok
+}
+
 // A type which represents an immutable sequence of arrow.Date64 values.
 type Date64 struct {
 	array
@@ -880,6 +1118,23 @@ func arrayEqualDate64(left, right *Date64) bool {
 	return true
 }
 
+func minMaxDate64(a *Date64) (min, max arrow.Date64, ok bool) {
+	for i, v := range a.values {
+		if a.IsNull(i) {
+			continue
+		}
+		switch {
+		case !ok:
+			min, max, ok = v, v, true
+		case v < min:
+			min = v
+		case v > max:
+			max = v
+		}
+	}
+	return min, max, ok
+}
+
 // A type which represents an immutable sequence of arrow.Duration values.
 type Duration struct {
 	array
@@ -936,3 +1191,20 @@ func arrayEqualDuration(left, right *Duration) bool {
 	}
 	return true
 }
+
+func minMaxDuration(a *Duration) (min, max arrow.Duration, ok bool) {
+	for i, v := range a.values {
+		if a.IsNull(i) {
+			continue
+		}
+		switch {
+		case !ok:
+			min, max, ok = v, v, true
+		case v < min:
+			min = v
+		case v > max:
+			max = v
+		}
+	}
+	return min, max, ok
+}
@@ -62,6 +62,7 @@ func (b *Int64Builder) Append(v int64) {
 }
 
 func (b *Int64Builder) AppendNull() {
+	b.builder.checkNull()
 	b.Reserve(1)
 	b.UnsafeAppendBoolToBitmap(false)
 }
@@ -73,6 +74,9 @@ func (b *Int64Builder) UnsafeAppend(v int64) {
 }
 
 func (b *Int64Builder) UnsafeAppendBoolToBitmap(isValid bool) {
+	if !isValid {
+		b.builder.checkNull()
+	}
 	if isValid {
 		bitutil.SetBit(b.nullBitmap.Bytes(), b.length)
 	} else {
@@ -85,6 +89,7 @@ func (b *Int64Builder) UnsafeAppendBoolToBitmap(isValid bool) {
 // in v are valid (not null). The valid slice must either be empty or be equal in length to v. If empty,
 // all values in v are appended and considered valid.
 func (b *Int64Builder) AppendValues(v []int64, valid []bool) {
+	b.builder.checkValid(valid)
 	if len(v) != len(valid) && len(valid) != 0 {
 		panic("len(v) != len(valid) && len(valid) != 0")
 	}
@@ -198,6 +203,7 @@ func (b *Uint64Builder) Append(v uint64) {
 }
 
 func (b *Uint64Builder) AppendNull() {
+	b.builder.checkNull()
 	b.Reserve(1)
 	b.UnsafeAppendBoolToBitmap(false)
 }
@@ -209,6 +215,9 @@ func (b *Uint64Builder) UnsafeAppend(v uint64) {
 }
 
 func (b *Uint64Builder) UnsafeAppendBoolToBitmap(isValid bool) {
+	if !isValid {
+		b.builder.checkNull()
+	}
 	if isValid {
 		bitutil.SetBit(b.nullBitmap.Bytes(), b.length)
 	} else {
@@ -221,6 +230,7 @@ func (b *Uint64Builder) UnsafeAppendBoolToBitmap(isValid bool) {
 // in v are valid (not null). The valid slice must either be empty or be equal in length to v. If empty,
 // all values in v are appended and considered valid.
 func (b *Uint64Builder) AppendValues(v []uint64, valid []bool) {
+	b.builder.checkValid(valid)
 	if len(v) != len(valid) && len(valid) != 0 {
 		panic("len(v) != len(valid) && len(valid) != 0")
 	}
@@ -334,6 +344,7 @@ func (b *Float64Builder) Append(v float64) {
 }
 
 func (b *Float64Builder) AppendNull() {
+	b.builder.checkNull()
 	b.Reserve(1)
 	b.UnsafeAppendBoolToBitmap(false)
 }
@@ -345,6 +356,9 @@ func (b *Float64Builder) UnsafeAppend(v float64) {
 }
 
 func (b *Float64Builder) UnsafeAppendBoolToBitmap(isValid bool) {
+	if !isValid {
+		b.builder.checkNull()
+	}
 	if isValid {
 		bitutil.SetBit(b.nullBitmap.Bytes(), b.length)
 	} else {
@@ -357,6 +371,7 @@ func (b *Float64Builder) UnsafeAppendBoolToBitmap(isValid bool) {
 // in v are valid (not null). The valid slice must either be empty or be equal in length to v. If empty,
 // all values in v are appended and considered valid.
 func (b *Float64Builder) AppendValues(v []float64, valid []bool) {
+	b.builder.checkValid(valid)
 	if len(v) != len(valid) && len(valid) != 0 {
 		panic("len(v) != len(valid) && len(valid) != 0")
 	}
@@ -470,6 +485,7 @@ func (b *Int32Builder) Append(v int32) {
 }
 
 func (b *Int32Builder) AppendNull() {
+	b.builder.checkNull()
 	b.Reserve(1)
 	b.UnsafeAppendBoolToBitmap(false)
 }
@@ -481,6 +497,9 @@ func (b *Int32Builder) UnsafeAppend(v int32) {
 }
 
 func (b *Int32Builder) UnsafeAppendBoolToBitmap(isValid bool) {
+	if !isValid {
+		b.builder.checkNull()
+	}
 	if isValid {
 		bitutil.SetBit(b.nullBitmap.Bytes(), b.length)
 	} else {
@@ -493,6 +512,7 @@ func (b *Int32Builder) UnsafeAppendBoolToBitmap(isValid bool) {
 // in v are valid (not null). The valid slice must either be empty or be equal in length to v. If empty,
 // all values in v are appended and considered valid.
 func (b *Int32Builder) AppendValues(v []int32, valid []bool) {
+	b.builder.checkValid(valid)
 	if len(v) != len(valid) && len(valid) != 0 {
 		panic("len(v) != len(valid) && len(valid) != 0")
 	}
@@ -606,6 +626,7 @@ func (b *Uint32Builder) Append(v uint32) {
 }
 
 func (b *Uint32Builder) AppendNull() {
+	b.builder.checkNull()
 	b.Reserve(1)
 	b.UnsafeAppendBoolToBitmap(false)
 }
@@ -617,6 +638,9 @@ func (b *Uint32Builder) UnsafeAppend(v uint32) {
 }
 
 func (b *Uint32Builder) UnsafeAppendBoolToBitmap(isValid bool) {
+	if !isValid {
+		b.builder.checkNull()
+	}
 	if isValid {
 		bitutil.SetBit(b.nullBitmap.Bytes(), b.length)
 	} else {
@@ -629,6 +653,7 @@ func (b *Uint32Builder) UnsafeAppendBoolToBitmap(isValid bool) {
 // in v are valid (not null). The valid slice must either be empty or be equal in length to v. If empty,
 // all values in v are appended and considered valid.
 func (b *Uint32Builder) AppendValues(v []uint32, valid []bool) {
+	b.builder.checkValid(valid)
 	if len(v) != len(valid) && len(valid) != 0 {
 		panic("len(v) != len(valid) && len(valid) != 0")
 	}
@@ -742,6 +767,7 @@ func (b *Float32Builder) Append(v float32) {
 }
 
 func (b *Float32Builder) AppendNull() {
+	b.builder.checkNull()
 	b.Reserve(1)
 	b.UnsafeAppendBoolToBitmap(false)
 }
@@ -753,6 +779,9 @@ func (b *Float32Builder) UnsafeAppend(v float32) {
 }
 
 func (b *Float32Builder) UnsafeAppendBoolToBitmap(isValid bool) {
+	if !isValid {
+		b.builder.checkNull()
+	}
 	if isValid {
 		bitutil.SetBit(b.nullBitmap.Bytes(), b.length)
 	} else {
@@ -765,6 +794,7 @@ func (b *Float32Builder) UnsafeAppendBoolToBitmap(isValid bool) {
 // in v are valid (not null). The valid slice must either be empty or be equal in length to v. If empty,
 // all values in v are appended and considered valid.
 func (b *Float32Builder) AppendValues(v []float32, valid []bool) {
+	b.builder.checkValid(valid)
 	if len(v) != len(valid) && len(valid) != 0 {
 		panic("len(v) != len(valid) && len(valid) != 0")
 	}
@@ -878,6 +908,7 @@ func (b *Int16Builder) Append(v int16) {
 }
 
 func (b *Int16Builder) AppendNull() {
+	b.builder.checkNull()
 	b.Reserve(1)
 	b.UnsafeAppendBoolToBitmap(false)
 }
@@ -889,6 +920,9 @@ func (b *Int16Builder) UnsafeAppend(v int16) {
 }
 
 func (b *Int16Builder) UnsafeAppendBoolToBitmap(isValid bool) {
+	if !isValid {
+		b.builder.checkNull()
+	}
 	if isValid {
 		bitutil.SetBit(b.nullBitmap.Bytes(), b.length)
 	} else {
@@ -901,6 +935,7 @@ func (b *Int16Builder) UnsafeAppendBoolToBitmap(isValid bool) {
 // in v are valid (not null). The valid slice must either be empty or be equal in length to v. If empty,
 // all values in v are appended and considered valid.
 func (b *Int16Builder) AppendValues(v []int16, valid []bool) {
+	b.builder.checkValid(valid)
 	if len(v) != len(valid) && len(valid) != 0 {
 		panic("len(v) != len(valid) && len(valid) != 0")
 	}
@@ -1014,6 +1049,7 @@ func (b *Uint16Builder) Append(v uint16) {
 }
 
 func (b *Uint16Builder) AppendNull() {
+	b.builder.checkNull()
 	b.Reserve(1)
 	b.UnsafeAppendBoolToBitmap(false)
 }
@@ -1025,6 +1061,9 @@ func (b *Uint16Builder) UnsafeAppend(v uint16) {
 }
 
 func (b *Uint16Builder) UnsafeAppendBoolToBitmap(isValid bool) {
+	if !isValid {
+		b.builder.checkNull()
+	}
 	if isValid {
 		bitutil.SetBit(b.nullBitmap.Bytes(), b.length)
 	} else {
@@ -1037,6 +1076,7 @@ func (b *Uint16Builder) UnsafeAppendBoolToBitmap(isValid bool) {
 // in v are valid (not null). The valid slice must either be empty or be equal in length to v. If empty,
 // all values in v are appended and considered valid.
 func (b *Uint16Builder) AppendValues(v []uint16, valid []bool) {
+	b.builder.checkValid(valid)
 	if len(v) != len(valid) && len(valid) != 0 {
 		panic("len(v) != len(valid) && len(valid) != 0")
 	}
@@ -1150,6 +1190,7 @@ func (b *Int8Builder) Append(v int8) {
 }
 
 func (b *Int8Builder) AppendNull() {
+	b.builder.checkNull()
 	b.Reserve(1)
 	b.UnsafeAppendBoolToBitmap(false)
 }
@@ -1161,6 +1202,9 @@ func (b *Int8Builder) UnsafeAppend(v int8) {
 }
 
 func (b *Int8Builder) UnsafeAppendBoolToBitmap(isValid bool) {
+	if !isValid {
+		b.builder.checkNull()
+	}
 	if isValid {
 		bitutil.SetBit(b.nullBitmap.Bytes(), b.length)
 	} else {
@@ -1173,6 +1217,7 @@ func (b *Int8Builder) UnsafeAppendBoolToBitmap(isValid bool) {
 // in v are valid (not null). The valid slice must either be empty or be equal in length to v. If empty,
 // all values in v are appended and considered valid.
 func (b *Int8Builder) AppendValues(v []int8, valid []bool) {
+	b.builder.checkValid(valid)
 	if len(v) != len(valid) && len(valid) != 0 {
 		panic("len(v) != len(valid) && len(valid) != 0")
 	}
@@ -1286,6 +1331,7 @@ func (b *Uint8Builder) Append(v uint8) {
 }
 
 func (b *Uint8Builder) AppendNull() {
+	b.builder.checkNull()
 	b.Reserve(1)
 	b.UnsafeAppendBoolToBitmap(false)
 }
@@ -1297,6 +1343,9 @@ func (b *Uint8Builder) UnsafeAppend(v uint8) {
 }
 
 func (b *Uint8Builder) UnsafeAppendBoolToBitmap(isValid bool) {
+	if !isValid {
+		b.builder.checkNull()
+	}
 	if isValid {
 		bitutil.SetBit(b.nullBitmap.Bytes(), b.length)
 	} else {
@@ -1309,6 +1358,7 @@ func (b *Uint8Builder) UnsafeAppendBoolToBitmap(isValid bool) {
 // in v are valid (not null). The valid slice must either be empty or be equal in length to v. If empty,
 // all values in v are appended and considered valid.
 func (b *Uint8Builder) AppendValues(v []uint8, valid []bool) {
+	b.builder.checkValid(valid)
 	if len(v) != len(valid) && len(valid) != 0 {
 		panic("len(v) != len(valid) && len(valid) != 0")
 	}
@@ -1423,6 +1473,7 @@ func (b *TimestampBuilder) Append(v arrow.Timestamp) {
 }
 
 func (b *TimestampBuilder) AppendNull() {
+	b.builder.checkNull()
 	b.Reserve(1)
 	b.UnsafeAppendBoolToBitmap(false)
 }
@@ -1434,6 +1485,9 @@ func (b *TimestampBuilder) UnsafeAppend(v arrow.Timestamp) {
 }
 
 func (b *TimestampBuilder) UnsafeAppendBoolToBitmap(isValid bool) {
+	if !isValid {
+		b.builder.checkNull()
+	}
 	if isValid {
 		bitutil.SetBit(b.nullBitmap.Bytes(), b.length)
 	} else {
@@ -1446,6 +1500,7 @@ func (b *TimestampBuilder) UnsafeAppendBoolToBitmap(isValid bool) {
 // in v are valid (not null). The valid slice must either be empty or be equal in length to v. If empty,
 // all values in v are appended and considered valid.
 func (b *TimestampBuilder) AppendValues(v []arrow.Timestamp, valid []bool) {
+	b.builder.checkValid(valid)
 	if len(v) != len(valid) && len(valid) != 0 {
 		panic("len(v) != len(valid) && len(valid) != 0")
 	}
@@ -1560,6 +1615,7 @@ func (b *Time32Builder) Append(v arrow.Time32) {
 }
 
 func (b *Time32Builder) AppendNull() {
+	b.builder.checkNull()
 	b.Reserve(1)
 	b.UnsafeAppendBoolToBitmap(false)
 }
@@ -1571,6 +1627,9 @@ func (b *Time32Builder) UnsafeAppend(v arrow.Time32) {
 }
 
 func (b *Time32Builder) UnsafeAppendBoolToBitmap(isValid bool) {
+	if !isValid {
+		b.builder.checkNull()
+	}
 	if isValid {
 		bitutil.SetBit(b.nullBitmap.Bytes(), b.length)
 	} else {
@@ -1583,6 +1642,7 @@ func (b *Time32Builder) UnsafeAppendBoolToBitmap(isValid bool) {
 // in v are valid (not null). The valid slice must either be empty or be equal in length to v. If empty,
 // all values in v are appended and considered valid.
 func (b *Time32Builder) AppendValues(v []arrow.Time32, valid []bool) {
+	b.builder.checkValid(valid)
 	if len(v) != len(valid) && len(valid) != 0 {
 		panic("len(v) != len(valid) && len(valid) != 0")
 	}
@@ -1697,6 +1757,7 @@ func (b *Time64Builder) Append(v arrow.Time64) {
 }
 
 func (b *Time64Builder) AppendNull() {
+	b.builder.checkNull()
 	b.Reserve(1)
 	b.UnsafeAppendBoolToBitmap(false)
 }
@@ -1708,6 +1769,9 @@ func (b *Time64Builder) UnsafeAppend(v arrow.Time64) {
 }
 
 func (b *Time64Builder) UnsafeAppendBoolToBitmap(isValid bool) {
+	if !isValid {
+		b.builder.checkNull()
+	}
 	if isValid {
 		bitutil.SetBit(b.nullBitmap.Bytes(), b.length)
 	} else {
@@ -1720,6 +1784,7 @@ func (b *Time64Builder) UnsafeAppendBoolToBitmap(isValid bool) {
 // in v are valid (not null). The valid slice must either be empty or be equal in length to v. If empty,
 // all values in v are appended and considered valid.
 func (b *Time64Builder) AppendValues(v []arrow.Time64, valid []bool) {
+	b.builder.checkValid(valid)
 	if len(v) != len(valid) && len(valid) != 0 {
 		panic("len(v) != len(valid) && len(valid) != 0")
 	}
@@ -1833,6 +1898,7 @@ func (b *Date32Builder) Append(v arrow.Date32) {
 }
 
 func (b *Date32Builder) AppendNull() {
+	b.builder.checkNull()
 	b.Reserve(1)
 	b.UnsafeAppendBoolToBitmap(false)
 }
@@ -1844,6 +1910,9 @@ func (b *Date32Builder) UnsafeAppend(v arrow.Date32) {
 }
 
 func (b *Date32Builder) UnsafeAppendBoolToBitmap(isValid bool) {
+	if !isValid {
+		b.builder.checkNull()
+	}
 	if isValid {
 		bitutil.SetBit(b.nullBitmap.Bytes(), b.length)
 	} else {
@@ -1856,6 +1925,7 @@ func (b *Date32Builder) UnsafeAppendBoolToBitmap(isValid bool) {
 // in v are valid (not null). The valid slice must either be empty or be equal in length to v. If empty,
 // all values in v are appended and considered valid.
 func (b *Date32Builder) AppendValues(v []arrow.Date32, valid []bool) {
+	b.builder.checkValid(valid)
 	if len(v) != len(valid) && len(valid) != 0 {
 		panic("len(v) != len(valid) && len(valid) != 0")
 	}
@@ -1969,6 +2039,7 @@ func (b *Date64Builder) Append(v arrow.Date64) {
 }
 
 func (b *Date64Builder) AppendNull() {
+	b.builder.checkNull()
 	b.Reserve(1)
 	b.UnsafeAppendBoolToBitmap(false)
 }
@@ -1980,6 +2051,9 @@ func (b *Date64Builder) UnsafeAppend(v arrow.Date64) {
 }
 
 func (b *Date64Builder) UnsafeAppendBoolToBitmap(isValid bool) {
+	if !isValid {
+		b.builder.checkNull()
+	}
 	if isValid {
 		bitutil.SetBit(b.nullBitmap.Bytes(), b.length)
 	} else {
@@ -1992,6 +2066,7 @@ func (b *Date64Builder) UnsafeAppendBoolToBitmap(isValid bool) {
 // in v are valid (not null). The valid slice must either be empty or be equal in length to v. If empty,
 // all values in v are appended and considered valid.
 func (b *Date64Builder) AppendValues(v []arrow.Date64, valid []bool) {
+	b.builder.checkValid(valid)
 	if len(v) != len(valid) && len(valid) != 0 {
 		panic("len(v) != len(valid) && len(valid) != 0")
 	}
@@ -2106,6 +2181,7 @@ func (b *DurationBuilder) Append(v arrow.Duration) {
 }
 
 func (b *DurationBuilder) AppendNull() {
+	b.builder.checkNull()
 	b.Reserve(1)
 	b.UnsafeAppendBoolToBitmap(false)
 }
@@ -2117,6 +2193,9 @@ func (b *DurationBuilder) UnsafeAppend(v arrow.Duration) {
 }
 
 func (b *DurationBuilder) UnsafeAppendBoolToBitmap(isValid bool) {
+	if !isValid {
+		b.builder.checkNull()
+	}
 	if isValid {
 		bitutil.SetBit(b.nullBitmap.Bytes(), b.length)
 	} else {
@@ -2129,6 +2208,7 @@ func (b *DurationBuilder) UnsafeAppendBoolToBitmap(isValid bool) {
 // in v are valid (not null). The valid slice must either be empty or be equal in length to v. If empty,
 // all values in v are appended and considered valid.
 func (b *DurationBuilder) AppendValues(v []arrow.Duration, valid []bool) {
+	b.builder.checkValid(valid)
 	if len(v) != len(valid) && len(valid) != 0 {
 		panic("len(v) != len(valid) && len(valid) != 0")
 	}
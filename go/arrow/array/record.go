@@ -261,10 +261,31 @@ type RecordBuilder struct {
 	mem      memory.Allocator
 	schema   *arrow.Schema
 	fields   []Builder
+
+	strict bool // whether to enforce the nullability of fields
+}
+
+// RecordBuilderOption is a functional option to configure a RecordBuilder.
+type RecordBuilderOption func(*RecordBuilder)
+
+// WithNullabilityCheck enables the enforcement of the schema's fields
+// nullability when appending values.
+// With this option, appending a null value to the builder of a non-nullable
+// field panics, leaving that builder unchanged.
+//
+// The nullability of the fields of struct types is enforced as well, at any
+// depth, including for the elements of list types.
+// The elements of list types themselves are always nullable.
+// Null struct entries appended with StructBuilder.Append or AppendNull hold
+// null placeholders in all their fields, whatever their nullability.
+func WithNullabilityCheck() RecordBuilderOption {
+	return func(b *RecordBuilder) {
+		b.strict = true
+	}
 }
 
 // NewRecordBuilder returns a builder, using the provided memory allocator and a schema.
-func NewRecordBuilder(mem memory.Allocator, schema *arrow.Schema) *RecordBuilder {
+func NewRecordBuilder(mem memory.Allocator, schema *arrow.Schema, opts ...RecordBuilderOption) *RecordBuilder {
 	b := &RecordBuilder{
 		refCount: 1,
 		mem:      mem,
@@ -272,13 +293,39 @@ func NewRecordBuilder(mem memory.Allocator, schema *arrow.Schema) *RecordBuilder
 		fields:   make([]Builder, len(schema.Fields())),
 	}
 
+	for _, opt := range opts {
+		opt(b)
+	}
+
 	for i, f := range schema.Fields() {
 		b.fields[i] = newBuilder(b.mem, f.Type)
+		if b.strict {
+			checkNullability(b.fields[i], f)
+		}
 	}
 
 	return b
 }
 
+// checkNullability configures the builder of the values of field f, and the
+// builders of its children, to reject null values if they are not nullable.
+func checkNullability(b Builder, f arrow.Field) {
+	if !f.Nullable {
+		b.setNonNullable(&f)
+	}
+	switch b := b.(type) {
+	case *StructBuilder:
+		for i, child := range b.dtype.(*arrow.StructType).Fields() {
+			child.Name = f.Name + "." + child.Name
+			checkNullability(b.fields[i], child)
+		}
+	case *ListBuilder:
+		checkNullability(b.values, arrow.Field{Name: f.Name, Type: b.etype, Nullable: true})
+	case *FixedSizeListBuilder:
+		checkNullability(b.values, arrow.Field{Name: f.Name, Type: b.etype, Nullable: true})
+	}
+}
+
 // Retain increases the reference count by 1.
 // Retain may be called simultaneously from multiple goroutines.
 func (b *RecordBuilder) Retain() {
@@ -308,6 +355,21 @@ func (b *RecordBuilder) Reserve(size int) {
 	}
 }
 
+// Validate checks that the values appended so far to the fields' builders
+// satisfy the nullability constraints of the schema.
+// Validate returns an error if a non-nullable field holds null values.
+func (b *RecordBuilder) Validate() error {
+	for i, f := range b.schema.Fields() {
+		if f.Nullable {
+			continue
+		}
+		if n := b.fields[i].NullN(); n > 0 {
+			return fmt.Errorf("arrow/array: non-nullable field %q has %d null values", f.Name, n)
+		}
+	}
+	return nil
+}
+
 // NewRecord creates a new record from the memory buffers and resets the
 // RecordBuilder so it can be used to build a new record.
 //
@@ -388,3 +388,193 @@ func TestRecordBuilder(t *testing.T) {
 		t.Fatalf("invalid column name: got=%q, want=%q", got, want)
 	}
 }
+
+func TestRecordBuilderNullability(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema(
+		[]arrow.Field{
+			arrow.Field{Name: "f1-i32", Type: arrow.PrimitiveTypes.Int32},
+			arrow.Field{Name: "f2-f64", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+			arrow.Field{Name: "f3-str", Type: arrow.BinaryTypes.String},
+			arrow.Field{Name: "f4-bool", Type: arrow.FixedWidthTypes.Boolean},
+			arrow.Field{Name: "f5-struct", Type: arrow.StructOf(
+				arrow.Field{Name: "x", Type: arrow.PrimitiveTypes.Int32},
+				arrow.Field{Name: "y", Type: arrow.PrimitiveTypes.Int32, Nullable: true},
+			), Nullable: true},
+			arrow.Field{Name: "f6-list", Type: arrow.ListOf(arrow.StructOf(
+				arrow.Field{Name: "z", Type: arrow.PrimitiveTypes.Int32},
+			))},
+		},
+		nil,
+	)
+
+	b := array.NewRecordBuilder(mem, schema, array.WithNullabilityCheck())
+	defer b.Release()
+
+	b.Field(0).(*array.Int32Builder).AppendValues([]int32{1, 2, 3}, nil)
+	b.Field(1).(*array.Float64Builder).AppendValues([]float64{1, 2, 3}, []bool{true, false, true})
+	b.Field(2).(*array.StringBuilder).AppendValues([]string{"a", "b", "c"}, nil)
+	b.Field(3).(*array.BooleanBuilder).AppendValues([]bool{true, false, true}, nil)
+	sb := b.Field(4).(*array.StructBuilder)
+	sb.AppendValues([]bool{true, false, true})
+	sb.FieldBuilder(0).(*array.Int32Builder).AppendValues([]int32{1, 0, 3}, nil)
+	sb.FieldBuilder(1).(*array.Int32Builder).AppendValues([]int32{1, 2, 3}, []bool{true, false, true})
+	lb := b.Field(5).(*array.ListBuilder)
+	lsb := lb.ValueBuilder().(*array.StructBuilder)
+	lb.Append(true)
+	lsb.AppendValues([]bool{true, false})
+	lsb.FieldBuilder(0).(*array.Int32Builder).AppendValues([]int32{1, 2}, nil)
+	lb.Append(true)
+	lb.Append(true)
+
+	if err := b.Validate(); err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+
+	rec := b.NewRecord()
+	rec.Release()
+
+	for _, tc := range []struct {
+		name string
+		f    func()
+		want string
+	}{
+		{
+			name: "append-null",
+			f:    func() { b.Field(0).AppendNull() },
+			want: `arrow/array: null value appended to non-nullable field "f1-i32"`,
+		},
+		{
+			name: "append-values",
+			f:    func() { b.Field(0).(*array.Int32Builder).AppendValues([]int32{1, 2}, []bool{true, false}) },
+			want: `arrow/array: null value appended to non-nullable field "f1-i32"`,
+		},
+		{
+			name: "append-string-values",
+			f:    func() { b.Field(2).(*array.StringBuilder).AppendValues([]string{"a", "b"}, []bool{false, true}) },
+			want: `arrow/array: null value appended to non-nullable field "f3-str"`,
+		},
+		{
+			name: "append-string-null",
+			f:    func() { b.Field(2).AppendNull() },
+			want: `arrow/array: null value appended to non-nullable field "f3-str"`,
+		},
+		{
+			name: "append-bool-null",
+			f:    func() { b.Field(3).AppendNull() },
+			want: `arrow/array: null value appended to non-nullable field "f4-bool"`,
+		},
+		{
+			name: "append-bool-values",
+			f:    func() { b.Field(3).(*array.BooleanBuilder).AppendValues([]bool{true, false}, []bool{true, false}) },
+			want: `arrow/array: null value appended to non-nullable field "f4-bool"`,
+		},
+		{
+			name: "append-struct-field-null",
+			f:    func() { sb.FieldBuilder(0).AppendNull() },
+			want: `arrow/array: null value appended to non-nullable field "f5-struct.x"`,
+		},
+		{
+			name: "append-list-null",
+			f:    func() { lb.AppendNull() },
+			want: `arrow/array: null value appended to non-nullable field "f6-list"`,
+		},
+		{
+			name: "append-list-elem-field-null",
+			f:    func() { lsb.FieldBuilder(0).AppendNull() },
+			want: `arrow/array: null value appended to non-nullable field "f6-list.z"`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			defer func() {
+				e := recover()
+				if e == nil {
+					t.Fatalf("expected a panic")
+				}
+				if got := e.(error).Error(); got != tc.want {
+					t.Fatalf("invalid panic: got=%q, want=%q", got, tc.want)
+				}
+			}()
+			tc.f()
+		})
+	}
+
+	// rejected nulls leave the builders unchanged.
+	for i, f := range b.Fields() {
+		if f.Len() != 0 || f.NullN() != 0 {
+			t.Fatalf("field %d: invalid builder state: len=%d, nulls=%d", i, f.Len(), f.NullN())
+		}
+	}
+
+	b.Field(0).(*array.Int32Builder).Append(4)
+	b.Field(1).AppendNull()
+	b.Field(2).(*array.StringBuilder).Append("d")
+	b.Field(3).(*array.BooleanBuilder).Append(false)
+	sb.Append(true)
+	sb.FieldBuilder(0).(*array.Int32Builder).Append(5)
+	sb.FieldBuilder(1).AppendNull()
+	lb.Append(true)
+	lsb.AppendNull()
+	lsb.FieldBuilder(0).(*array.Int32Builder).Append(0)
+
+	if err := b.Validate(); err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+
+	rec = b.NewRecord()
+	defer rec.Release()
+
+	if got, want := rec.NumRows(), int64(1); got != want {
+		t.Fatalf("invalid number of rows: got=%d, want=%d", got, want)
+	}
+	if got, want := rec.Column(0).(*array.Int32).Value(0), int32(4); got != want {
+		t.Fatalf("invalid value: got=%d, want=%d", got, want)
+	}
+	if got, want := rec.Column(2).(*array.String).Value(0), "d"; got != want {
+		t.Fatalf("invalid value: got=%q, want=%q", got, want)
+	}
+	if got, want := rec.Column(3).(*array.Boolean).Value(0), false; got != want {
+		t.Fatalf("invalid value: got=%v, want=%v", got, want)
+	}
+	if got, want := rec.Column(4).(*array.Struct).Field(1).NullN(), 1; got != want {
+		t.Fatalf("invalid number of nulls: got=%d, want=%d", got, want)
+	}
+}
+
+func TestRecordBuilderValidate(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema(
+		[]arrow.Field{
+			arrow.Field{Name: "f1-i32", Type: arrow.PrimitiveTypes.Int32},
+			arrow.Field{Name: "f2-f64", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+		},
+		nil,
+	)
+
+	b := array.NewRecordBuilder(mem, schema)
+	defer b.Release()
+
+	b.Field(0).(*array.Int32Builder).AppendValues([]int32{1, 2}, nil)
+	b.Field(0).AppendNull()
+	b.Field(1).(*array.Float64Builder).AppendValues([]float64{1, 2, 3}, []bool{true, false, true})
+
+	err := b.Validate()
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	if got, want := err.Error(), `arrow/array: non-nullable field "f1-i32" has 1 null values`; got != want {
+		t.Fatalf("invalid error: got=%q, want=%q", got, want)
+	}
+
+	// without WithNullabilityCheck, the record can still be created.
+	rec := b.NewRecord()
+	defer rec.Release()
+
+	if got, want := rec.Column(0).NullN(), 1; got != want {
+		t.Fatalf("invalid number of nulls: got=%d, want=%d", got, want)
+	}
+}
@@ -0,0 +1,162 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array
+
+import (
+	"bytes"
+)
+
+// Statistics holds summary statistics about the values of an array.
+type Statistics struct {
+	NullN int // number of null values
+
+	// Min and Max hold the smallest and largest valid values of the array,
+	// with the Go type returned by the array's Value method.
+	// Min and Max are nil when the array has no valid values or when
+	// the values of the array are not ordered.
+	// NaN values are ignored.
+	Min, Max interface{}
+}
+
+// ArrayStatistics returns the statistics of the provided array.
+//
+// Statistics are computed on first use and cached on the array's Data,
+// so that later calls for arrays sharing the same Data do not scan the values
+// again. Slicing an array does not carry the statistics over to the slice.
+// Statistics may also be attached with Data.SetStatistics.
+//
+// Min and Max are computed for boolean, binary, string, integer,
+// floating-point (except float16), date, time, timestamp and duration arrays.
+func ArrayStatistics(arr Interface) Statistics {
+	data := arr.Data()
+	if stats, ok := data.stats.Load().(*Statistics); ok {
+		return *stats
+	}
+
+	stats := computeStatistics(arr)
+	data.stats.Store(&stats)
+	return stats
+}
+
+func computeStatistics(arr Interface) Statistics {
+	var (
+		stats    = Statistics{NullN: arr.NullN()}
+		min, max interface{}
+		ok       bool
+	)
+
+	switch arr := arr.(type) {
+	case *Boolean:
+		min, max, ok = minMaxBoolean(arr)
+	case *Binary:
+		var lo, hi []byte
+		lo, hi, ok = minMaxBinary(arr)
+		// copy the values out of the array buffers: statistics may outlive the array.
+		min, max = append([]byte(nil), lo...), append([]byte(nil), hi...)
+	case *String:
+		var lo, hi string
+		lo, hi, ok = minMaxString(arr)
+		min, max = string([]byte(lo)), string([]byte(hi))
+	case *Int8:
+		min, max, ok = minMaxInt8(arr)
+	case *Int16:
+		min, max, ok = minMaxInt16(arr)
+	case *Int32:
+		min, max, ok = minMaxInt32(arr)
+	case *Int64:
+		min, max, ok = minMaxInt64(arr)
+	case *Uint8:
+		min, max, ok = minMaxUint8(arr)
+	case *Uint16:
+		min, max, ok = minMaxUint16(arr)
+	case *Uint32:
+		min, max, ok = minMaxUint32(arr)
+	case *Uint64:
+		min, max, ok = minMaxUint64(arr)
+	case *Float32:
+		min, max, ok = minMaxFloat32(arr)
+	case *Float64:
+		min, max, ok = minMaxFloat64(arr)
+	case *Date32:
+		min, max, ok = minMaxDate32(arr)
+	case *Date64:
+		min, max, ok = minMaxDate64(arr)
+	case *Time32:
+		min, max, ok = minMaxTime32(arr)
+	case *Time64:
+		min, max, ok = minMaxTime64(arr)
+	case *Timestamp:
+		min, max, ok = minMaxTimestamp(arr)
+	case *Duration:
+		min, max, ok = minMaxDuration(arr)
+	}
+
+	if ok {
+		stats.Min, stats.Max = min, max
+	}
+	return stats
+}
+
+func minMaxBoolean(a *Boolean) (min, max bool, ok bool) {
+	min = true
+	for i := 0; i < a.Len(); i++ {
+		if a.IsNull(i) {
+			continue
+		}
+		v := a.Value(i)
+		min = min && v
+		max = max || v
+		ok = true
+	}
+	return min, max, ok
+}
+
+func minMaxBinary(a *Binary) (min, max []byte, ok bool) {
+	for i := 0; i < a.Len(); i++ {
+		if a.IsNull(i) {
+			continue
+		}
+		v := a.Value(i)
+		switch {
+		case !ok:
+			min, max, ok = v, v, true
+		case bytes.Compare(v, min) < 0:
+			min = v
+		case bytes.Compare(v, max) > 0:
+			max = v
+		}
+	}
+	return min, max, ok
+}
+
+func minMaxString(a *String) (min, max string, ok bool) {
+	for i := 0; i < a.Len(); i++ {
+		if a.IsNull(i) {
+			continue
+		}
+		v := a.Value(i)
+		switch {
+		case !ok:
+			min, max, ok = v, v, true
+		case v < min:
+			min = v
+		case v > max:
+			max = v
+		}
+	}
+	return min, max, ok
+}
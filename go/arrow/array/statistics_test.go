@@ -0,0 +1,177 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array_test
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+func TestArrayStatistics(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	valid := []bool{true, true, false, true, true}
+
+	for _, tc := range []struct {
+		name string
+		arr  func() array.Interface
+		want array.Statistics
+	}{
+		{
+			name: "int64",
+			arr: func() array.Interface {
+				b := array.NewInt64Builder(mem)
+				defer b.Release()
+				b.AppendValues([]int64{3, -1, -10, 7, 2}, valid)
+				return b.NewArray()
+			},
+			want: array.Statistics{NullN: 1, Min: int64(-1), Max: int64(7)},
+		},
+		{
+			name: "float64",
+			arr: func() array.Interface {
+				b := array.NewFloat64Builder(mem)
+				defer b.Release()
+				b.AppendValues([]float64{math.NaN(), 1.5, 0, -2, 4}, valid)
+				return b.NewArray()
+			},
+			want: array.Statistics{NullN: 1, Min: float64(-2), Max: float64(4)},
+		},
+		{
+			name: "string",
+			arr: func() array.Interface {
+				b := array.NewStringBuilder(mem)
+				defer b.Release()
+				b.AppendValues([]string{"bb", "c", "", "ab", "bc"}, valid)
+				return b.NewArray()
+			},
+			want: array.Statistics{NullN: 1, Min: "ab", Max: "c"},
+		},
+		{
+			name: "boolean",
+			arr: func() array.Interface {
+				b := array.NewBooleanBuilder(mem)
+				defer b.Release()
+				b.AppendValues([]bool{true, true, false, true, true}, valid)
+				return b.NewArray()
+			},
+			want: array.Statistics{NullN: 1, Min: true, Max: true},
+		},
+		{
+			name: "all-nulls",
+			arr: func() array.Interface {
+				b := array.NewInt32Builder(mem)
+				defer b.Release()
+				b.AppendNull()
+				b.AppendNull()
+				return b.NewArray()
+			},
+			want: array.Statistics{NullN: 2},
+		},
+		{
+			name: "unordered",
+			arr: func() array.Interface {
+				b := array.NewNullBuilder(mem)
+				defer b.Release()
+				b.AppendNull()
+				return b.NewArray()
+			},
+			want: array.Statistics{NullN: 1},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			arr := tc.arr()
+			defer arr.Release()
+
+			got := array.ArrayStatistics(arr)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("invalid statistics:\ngot= %#v\nwant=%#v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestArrayStatisticsCache(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	b := array.NewInt64Builder(mem)
+	defer b.Release()
+	b.AppendValues([]int64{1, 2, 3, 4, 5}, nil)
+	arr := b.NewInt64Array()
+	defer arr.Release()
+
+	want := array.Statistics{Min: int64(1), Max: int64(5)}
+	if got := array.ArrayStatistics(arr); !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid statistics:\ngot= %#v\nwant=%#v", got, want)
+	}
+
+	// statistics are shared by arrays built from the same data.
+	same := array.MakeFromData(arr.Data())
+	defer same.Release()
+
+	arr.Data().SetStatistics(array.Statistics{Min: int64(-1), Max: int64(42)})
+	want = array.Statistics{Min: int64(-1), Max: int64(42)}
+	if got := array.ArrayStatistics(same); !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid attached statistics:\ngot= %#v\nwant=%#v", got, want)
+	}
+
+	// statistics are not carried over to slices.
+	slice := array.NewSlice(arr, 1, 3)
+	defer slice.Release()
+
+	want = array.Statistics{Min: int64(2), Max: int64(3)}
+	if got := array.ArrayStatistics(slice); !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid slice statistics:\ngot= %#v\nwant=%#v", got, want)
+	}
+}
+
+func TestArrayStatisticsCopy(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	b := array.NewBinaryBuilder(mem, arrow.BinaryTypes.Binary)
+	defer b.Release()
+	b.AppendValues([][]byte{[]byte("b"), []byte("a"), []byte("c")}, nil)
+	arr := b.NewBinaryArray()
+
+	stats := array.ArrayStatistics(arr)
+	var (
+		raw = arr.ValueBytes()
+		min = stats.Min.([]byte)
+		max = stats.Max.([]byte)
+	)
+	for _, v := range [][]byte{min, max} {
+		if &v[0] == &raw[0] || &v[0] == &raw[1] || &v[0] == &raw[2] {
+			t.Fatalf("statistics share memory with the array values")
+		}
+	}
+	arr.Release()
+
+	if got, want := string(min), "a"; got != want {
+		t.Fatalf("invalid min: got=%q, want=%q", got, want)
+	}
+	if got, want := string(max), "c"; got != want {
+		t.Fatalf("invalid max: got=%q, want=%q", got, want)
+	}
+}
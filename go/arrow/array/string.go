@@ -161,6 +161,10 @@ func (b *StringBuilder) resize(newBits int, init func(int)) {
 	b.builder.resize(newBits, init)
 }
 
+func (b *StringBuilder) setNonNullable(field *arrow.Field) *arrow.Field {
+	return b.builder.setNonNullable(field)
+}
+
 // Reserve ensures there is enough space for appending n elements
 // by checking the capacity and calling Resize if necessary.
 func (b *StringBuilder) Reserve(n int) {
@@ -127,16 +127,23 @@ func (b *StructBuilder) Release() {
 }
 
 func (b *StructBuilder) Append(v bool) {
+	if !v {
+		b.builder.checkNull()
+	}
 	b.Reserve(1)
 	b.unsafeAppendBoolToBitmap(v)
 	if !v {
 		for _, f := range b.fields {
+			// the fields of a null entry are placeholders, even if non-nullable.
+			field := f.setNonNullable(nil)
 			f.AppendNull()
+			f.setNonNullable(field)
 		}
 	}
 }
 
 func (b *StructBuilder) AppendValues(valids []bool) {
+	b.builder.checkValid(valids)
 	b.Reserve(len(valids))
 	b.builder.unsafeAppendBoolsToBitmap(valids, len(valids))
 }
@@ -0,0 +1,478 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package compute provides functions operating on Arrow arrays.
+package compute // import "github.com/apache/arrow/go/arrow/compute"
+
+import (
+	"math"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/pkg/errors"
+)
+
+const (
+	secondsPerDay = 86400
+	msPerDay      = secondsPerDay * 1000
+)
+
+// Option configures a cast.
+type Option func(*config)
+
+type config struct {
+	mem    memory.Allocator
+	format string
+}
+
+func newConfig(opts ...Option) *config {
+	cfg := &config{
+		mem:    memory.NewGoAllocator(),
+		format: kDefaultFormat,
+	}
+
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return cfg
+}
+
+// WithAllocator specifies the Arrow memory allocator used while building arrays.
+func WithAllocator(mem memory.Allocator) Option {
+	return func(cfg *config) {
+		cfg.mem = mem
+	}
+}
+
+// WithFormat specifies the strftime-like format used to format timestamps
+// into strings and to parse strings into timestamps.
+//
+// The supported directives are:
+//
+//	%Y  year (2006)            %y  2-digit year (06)
+//	%m  month (01)             %b  abbreviated month name (Jan)
+//	%B  month name (January)   %d  day of the month (02)
+//	%e  space-padded day ( 2)  %j  day of the year (002)
+//	%a  abbreviated weekday    %A  weekday name (Monday)
+//	%H  hour (15)              %I  12-hour clock hour (03)
+//	%p  AM/PM                  %M  minute (04)
+//	%S  second (05)            %z  UTC offset (-0700)
+//	%Z  time zone name (MST)   %F  %Y-%m-%d
+//	%T  %H:%M:%S               %%  a literal %
+//
+// %S formats the seconds with as many fractional digits as the unit of the
+// timestamp requires, and parses seconds with optional fractional digits.
+// When parsing, month and weekday names may be full or abbreviated, and
+// are matched regardless of case.
+// Other characters of the format are literal text.
+// Cast returns an error if the format holds an unsupported directive.
+//
+// The default format is "%Y-%m-%d %H:%M:%S".
+func WithFormat(format string) Option {
+	return func(cfg *config) {
+		cfg.format = format
+	}
+}
+
+// Cast returns a new array holding the values of arr converted to the
+// provided data type.
+//
+// The supported conversions are:
+//   - Date32 to Date64 and Timestamp,
+//   - Date64 to Date32, Date64 and Timestamp,
+//   - Timestamp to Date32 and String,
+//   - String to Timestamp.
+//
+// Date64 values must be whole numbers of days: Cast returns an error
+// otherwise. Casting a Date64 array to Date64 only validates its values.
+//
+// Timestamps are interpreted in the time zone of their data type (UTC if
+// none): a timestamp is converted to the date of its calendar day in that
+// time zone, a date is converted to the timestamp of its midnight in that
+// time zone, and timestamps are formatted and parsed in that time zone,
+// see WithFormat.
+// The time zone is either a name of the IANA Time Zone database, such as
+// "America/New_York", or a fixed UTC offset, such as "+05:00".
+//
+// Cast returns an error if a value can not be represented in the target
+// type, such as a nanosecond timestamp outside of the years 1677-2262.
+//
+// The returned array must be Release()'d after use.
+func Cast(arr array.Interface, to arrow.DataType, opts ...Option) (array.Interface, error) {
+	cfg := newConfig(opts...)
+
+	switch arr := arr.(type) {
+	case *array.Date32:
+		switch to := to.(type) {
+		case *arrow.Date64Type:
+			return castDate32ToDate64(cfg, arr), nil
+		case *arrow.TimestampType:
+			return castDate32ToTimestamp(cfg, arr, to)
+		}
+
+	case *array.Date64:
+		err := validateDate64(arr)
+		if err != nil {
+			return nil, err
+		}
+		switch to := to.(type) {
+		case *arrow.Date32Type:
+			return castDate64ToDate32(cfg, arr)
+		case *arrow.Date64Type:
+			arr.Retain()
+			return arr, nil
+		case *arrow.TimestampType:
+			return castDate64ToTimestamp(cfg, arr, to)
+		}
+
+	case *array.Timestamp:
+		from := arr.DataType().(*arrow.TimestampType)
+		switch to.(type) {
+		case *arrow.Date32Type:
+			return castTimestampToDate32(cfg, arr, from)
+		case *arrow.StringType:
+			return castTimestampToString(cfg, arr, from)
+		}
+
+	case *array.String:
+		switch to := to.(type) {
+		case *arrow.TimestampType:
+			return castStringToTimestamp(cfg, arr, to)
+		}
+	}
+
+	return nil, errors.Errorf("arrow/compute: unsupported cast from %v to %v", arr.DataType(), to)
+}
+
+// validateDate64 checks that the values of arr are whole numbers of days.
+func validateDate64(arr *array.Date64) error {
+	for i, v := range arr.Date64Values() {
+		if v%msPerDay != 0 && arr.IsValid(i) {
+			return errors.Errorf("arrow/compute: Date64 value %d at index %d is not a whole number of days", v, i)
+		}
+	}
+	return nil
+}
+
+func castDate32ToDate64(cfg *config, arr *array.Date32) array.Interface {
+	var (
+		src = arr.Date32Values()
+		dst = make([]arrow.Date64, len(src))
+	)
+	for i, v := range src {
+		dst[i] = arrow.Date64(int64(v) * msPerDay)
+	}
+
+	bldr := array.NewDate64Builder(cfg.mem)
+	defer bldr.Release()
+	bldr.AppendValues(dst, validity(arr))
+	return bldr.NewArray()
+}
+
+func castDate64ToDate32(cfg *config, arr *array.Date64) (array.Interface, error) {
+	var (
+		src = arr.Date64Values()
+		dst = make([]arrow.Date32, len(src))
+	)
+	for i, v := range src {
+		if arr.IsNull(i) {
+			continue
+		}
+		days, ok := toDate32(int64(v) / msPerDay)
+		if !ok {
+			return nil, errOverflow(v, i, arrow.FixedWidthTypes.Date32)
+		}
+		dst[i] = days
+	}
+
+	bldr := array.NewDate32Builder(cfg.mem)
+	defer bldr.Release()
+	bldr.AppendValues(dst, validity(arr))
+	return bldr.NewArray(), nil
+}
+
+func castDate32ToTimestamp(cfg *config, arr *array.Date32, to *arrow.TimestampType) (array.Interface, error) {
+	loc, err := location(to)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		src  = arr.Date32Values()
+		dst  = make([]arrow.Timestamp, len(src))
+		unit = unitsPerSecond(to.Unit)
+	)
+	for i, v := range src {
+		if arr.IsNull(i) {
+			continue
+		}
+		ts, ok := fromTime(midnight(int64(v), loc), unit)
+		if !ok {
+			return nil, errOverflow(v, i, to)
+		}
+		dst[i] = arrow.Timestamp(ts)
+	}
+
+	bldr := array.NewTimestampBuilder(cfg.mem, to)
+	defer bldr.Release()
+	bldr.AppendValues(dst, validity(arr))
+	return bldr.NewArray(), nil
+}
+
+func castDate64ToTimestamp(cfg *config, arr *array.Date64, to *arrow.TimestampType) (array.Interface, error) {
+	loc, err := location(to)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		src  = arr.Date64Values()
+		dst  = make([]arrow.Timestamp, len(src))
+		unit = unitsPerSecond(to.Unit)
+	)
+	for i, v := range src {
+		if arr.IsNull(i) {
+			continue
+		}
+		ts, ok := fromTime(midnight(int64(v)/msPerDay, loc), unit)
+		if !ok {
+			return nil, errOverflow(v, i, to)
+		}
+		dst[i] = arrow.Timestamp(ts)
+	}
+
+	bldr := array.NewTimestampBuilder(cfg.mem, to)
+	defer bldr.Release()
+	bldr.AppendValues(dst, validity(arr))
+	return bldr.NewArray(), nil
+}
+
+func castTimestampToDate32(cfg *config, arr *array.Timestamp, from *arrow.TimestampType) (array.Interface, error) {
+	loc, err := location(from)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		src  = arr.TimestampValues()
+		dst  = make([]arrow.Date32, len(src))
+		unit = unitsPerSecond(from.Unit)
+	)
+	for i, v := range src {
+		if arr.IsNull(i) {
+			continue
+		}
+		y, m, d := toTime(int64(v), unit).In(loc).Date()
+		days, ok := toDate32(floorDiv(time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Unix(), secondsPerDay))
+		if !ok {
+			return nil, errOverflow(v, i, arrow.FixedWidthTypes.Date32)
+		}
+		dst[i] = days
+	}
+
+	bldr := array.NewDate32Builder(cfg.mem)
+	defer bldr.Release()
+	bldr.AppendValues(dst, validity(arr))
+	return bldr.NewArray(), nil
+}
+
+func castTimestampToString(cfg *config, arr *array.Timestamp, from *arrow.TimestampType) (array.Interface, error) {
+	loc, err := location(from)
+	if err != nil {
+		return nil, err
+	}
+
+	format, err := parseStrftime(cfg.format)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		src    = arr.TimestampValues()
+		dst    = make([]string, len(src))
+		unit   = unitsPerSecond(from.Unit)
+		digits = fractionDigits(from.Unit)
+	)
+	for i, v := range src {
+		if arr.IsNull(i) {
+			continue
+		}
+		dst[i] = format.format(toTime(int64(v), unit).In(loc), digits)
+	}
+
+	bldr := array.NewStringBuilder(cfg.mem)
+	defer bldr.Release()
+	bldr.AppendValues(dst, validity(arr))
+	return bldr.NewArray(), nil
+}
+
+func castStringToTimestamp(cfg *config, arr *array.String, to *arrow.TimestampType) (array.Interface, error) {
+	loc, err := location(to)
+	if err != nil {
+		return nil, err
+	}
+
+	format, err := parseStrftime(cfg.format)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		dst  = make([]arrow.Timestamp, arr.Len())
+		unit = unitsPerSecond(to.Unit)
+	)
+	for i := range dst {
+		if arr.IsNull(i) {
+			continue
+		}
+		t, err := format.parse(arr.Value(i), loc)
+		if err != nil {
+			return nil, errors.Wrapf(err, "arrow/compute: could not parse timestamp at index %d", i)
+		}
+		ts, ok := fromTime(t, unit)
+		if !ok {
+			return nil, errOverflow(arr.Value(i), i, to)
+		}
+		dst[i] = arrow.Timestamp(ts)
+	}
+
+	bldr := array.NewTimestampBuilder(cfg.mem, to)
+	defer bldr.Release()
+	bldr.AppendValues(dst, validity(arr))
+	return bldr.NewArray(), nil
+}
+
+// validity returns the validity of each element of arr, or nil if arr has no nulls.
+func validity(arr array.Interface) []bool {
+	if arr.NullN() == 0 {
+		return nil
+	}
+	valid := make([]bool, arr.Len())
+	for i := range valid {
+		valid[i] = arr.IsValid(i)
+	}
+	return valid
+}
+
+// unitsPerSecond returns the number of time units in a second.
+func unitsPerSecond(unit arrow.TimeUnit) int64 {
+	switch unit {
+	case arrow.Second:
+		return 1
+	case arrow.Millisecond:
+		return 1e3
+	case arrow.Microsecond:
+		return 1e6
+	case arrow.Nanosecond:
+		return 1e9
+	default:
+		panic(errors.Errorf("arrow/compute: invalid time unit %v", unit))
+	}
+}
+
+// floorDiv returns the quotient of a and b, rounded towards negative infinity.
+func floorDiv(a, b int64) int64 {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
+
+func toTime(v, unit int64) time.Time {
+	sec := floorDiv(v, unit)
+	nsec := (v - sec*unit) * (1e9 / unit)
+	return time.Unix(sec, nsec)
+}
+
+// midnight returns the start of the provided day since the epoch, in loc.
+func midnight(days int64, loc *time.Location) time.Time {
+	return time.Date(1970, time.January, 1, 0, 0, 0, 0, loc).AddDate(0, 0, int(days))
+}
+
+// toDate32 returns the provided number of days as a Date32, and false if
+// it overflows an int32.
+func toDate32(days int64) (arrow.Date32, bool) {
+	if days < math.MinInt32 || days > math.MaxInt32 {
+		return 0, false
+	}
+	return arrow.Date32(days), true
+}
+
+// fromTime returns t as a number of time units since the epoch.
+// fromTime returns false if that number overflows an int64.
+func fromTime(t time.Time, unit int64) (int64, bool) {
+	sec, ok := mulInt64(t.Unix(), unit)
+	if !ok {
+		return 0, false
+	}
+	frac := int64(t.Nanosecond()) / (1e9 / unit)
+	if sec > math.MaxInt64-frac {
+		return 0, false
+	}
+	return sec + frac, true
+}
+
+// mulInt64 returns a*b, and false if the product overflows an int64.
+// b must be positive.
+func mulInt64(a, b int64) (int64, bool) {
+	if a > math.MaxInt64/b || a < math.MinInt64/b {
+		return 0, false
+	}
+	return a * b, true
+}
+
+func errOverflow(v interface{}, i int, to arrow.DataType) error {
+	return errors.Errorf("arrow/compute: value %v at index %d overflows %v", v, i, to)
+}
+
+// fractionDigits returns the number of fractional second digits of a time unit.
+func fractionDigits(unit arrow.TimeUnit) int {
+	switch unit {
+	case arrow.Millisecond:
+		return 3
+	case arrow.Microsecond:
+		return 6
+	case arrow.Nanosecond:
+		return 9
+	default:
+		return 0
+	}
+}
+
+// location returns the time zone of dt: either UTC, a fixed UTC offset
+// (such as "+05:00") or a location of the IANA Time Zone database.
+func location(dt *arrow.TimestampType) (*time.Location, error) {
+	tz := dt.TimeZone
+	switch {
+	case tz == "":
+		return time.UTC, nil
+	case tz[0] == '+' || tz[0] == '-':
+		off, rest, err := parseOffset(tz)
+		if err != nil || rest != "" {
+			return nil, errors.Errorf("arrow/compute: invalid time zone %q", tz)
+		}
+		return time.FixedZone(tz, off), nil
+	}
+	loc, err := time.LoadLocation(dt.TimeZone)
+	if err != nil {
+		return nil, errors.Wrapf(err, "arrow/compute: invalid time zone %q", dt.TimeZone)
+	}
+	return loc, nil
+}
@@ -0,0 +1,378 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/compute"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+func TestCastTemporal(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	valid := []bool{true, false, true, true}
+
+	newDate32 := func(vs []arrow.Date32) array.Interface {
+		b := array.NewDate32Builder(mem)
+		defer b.Release()
+		b.AppendValues(vs, valid)
+		return b.NewArray()
+	}
+	newDate64 := func(vs []arrow.Date64) array.Interface {
+		b := array.NewDate64Builder(mem)
+		defer b.Release()
+		b.AppendValues(vs, valid)
+		return b.NewArray()
+	}
+	newTimestamp := func(dt *arrow.TimestampType, vs []arrow.Timestamp) array.Interface {
+		b := array.NewTimestampBuilder(mem, dt)
+		defer b.Release()
+		b.AppendValues(vs, valid)
+		return b.NewArray()
+	}
+	newString := func(vs []string) array.Interface {
+		b := array.NewStringBuilder(mem)
+		defer b.Release()
+		b.AppendValues(vs, valid)
+		return b.NewArray()
+	}
+
+	var (
+		tsS  = &arrow.TimestampType{Unit: arrow.Second}
+		tsMs = &arrow.TimestampType{Unit: arrow.Millisecond}
+		tsNY = &arrow.TimestampType{Unit: arrow.Second, TimeZone: "America/New_York"}
+		tsP5 = &arrow.TimestampType{Unit: arrow.Second, TimeZone: "+05:00"}
+	)
+
+	for _, tc := range []struct {
+		name string
+		arr  func() array.Interface
+		to   arrow.DataType
+		opts []compute.Option
+		want func() array.Interface
+	}{
+		{
+			name: "date32-date64",
+			arr:  func() array.Interface { return newDate32([]arrow.Date32{0, 0, 1, -1}) },
+			to:   arrow.FixedWidthTypes.Date64,
+			want: func() array.Interface { return newDate64([]arrow.Date64{0, 0, 86400000, -86400000}) },
+		},
+		{
+			name: "date64-date32",
+			arr:  func() array.Interface { return newDate64([]arrow.Date64{0, 0, 86400000, -86400000}) },
+			to:   arrow.FixedWidthTypes.Date32,
+			want: func() array.Interface { return newDate32([]arrow.Date32{0, 0, 1, -1}) },
+		},
+		{
+			name: "date64-date64",
+			arr:  func() array.Interface { return newDate64([]arrow.Date64{0, 0, 86400000, -86400000}) },
+			to:   arrow.FixedWidthTypes.Date64,
+			want: func() array.Interface { return newDate64([]arrow.Date64{0, 0, 86400000, -86400000}) },
+		},
+		{
+			name: "date32-timestamp",
+			arr:  func() array.Interface { return newDate32([]arrow.Date32{0, 0, 1, -1}) },
+			to:   tsMs,
+			want: func() array.Interface { return newTimestamp(tsMs, []arrow.Timestamp{0, 0, 86400000, -86400000}) },
+		},
+		{
+			name: "date64-timestamp",
+			arr:  func() array.Interface { return newDate64([]arrow.Date64{0, 0, 86400000, -86400000}) },
+			to:   tsS,
+			want: func() array.Interface { return newTimestamp(tsS, []arrow.Timestamp{0, 0, 86400, -86400}) },
+		},
+		{
+			name: "timestamp-date32",
+			arr:  func() array.Interface { return newTimestamp(tsS, []arrow.Timestamp{3600, 0, 86400 + 1, -1}) },
+			to:   arrow.FixedWidthTypes.Date32,
+			want: func() array.Interface { return newDate32([]arrow.Date32{0, 0, 1, -1}) },
+		},
+		{
+			name: "timestamp-date32-tz",
+			arr:  func() array.Interface { return newTimestamp(tsNY, []arrow.Timestamp{1577847600, 0, 0, 1577854800}) },
+			to:   arrow.FixedWidthTypes.Date32,
+			want: func() array.Interface { return newDate32([]arrow.Date32{18261, 0, -1, 18262}) },
+		},
+		{
+			name: "date32-timestamp-tz",
+			arr:  func() array.Interface { return newDate32([]arrow.Date32{18262, 0, 0, -1}) },
+			to:   tsNY,
+			want: func() array.Interface {
+				return newTimestamp(tsNY, []arrow.Timestamp{1577854800, 0, 18000, 18000 - 86400})
+			},
+		},
+		{
+			name: "date64-timestamp-offset",
+			arr:  func() array.Interface { return newDate64([]arrow.Date64{0, 0, 86400000, -86400000}) },
+			to:   tsP5,
+			want: func() array.Interface {
+				return newTimestamp(tsP5, []arrow.Timestamp{-18000, 0, 86400 - 18000, -86400 - 18000})
+			},
+		},
+		{
+			name: "timestamp-date32-offset",
+			arr:  func() array.Interface { return newTimestamp(tsP5, []arrow.Timestamp{-18000, 0, 86400 - 18001, 0}) },
+			to:   arrow.FixedWidthTypes.Date32,
+			want: func() array.Interface { return newDate32([]arrow.Date32{0, 0, 0, 0}) },
+		},
+		{
+			name: "timestamp-string-tz",
+			arr:  func() array.Interface { return newTimestamp(tsNY, []arrow.Timestamp{1577847600, 0, 0, 1577854800}) },
+			to:   arrow.BinaryTypes.String,
+			want: func() array.Interface {
+				return newString([]string{"2019-12-31 22:00:00", "", "1969-12-31 19:00:00", "2020-01-01 00:00:00"})
+			},
+		},
+		{
+			name: "timestamp-string-offset",
+			arr:  func() array.Interface { return newTimestamp(tsP5, []arrow.Timestamp{0, 0, 86400, -1}) },
+			to:   arrow.BinaryTypes.String,
+			opts: []compute.Option{compute.WithFormat("%F %T %z")},
+			want: func() array.Interface {
+				return newString([]string{"1970-01-01 05:00:00 +0500", "", "1970-01-02 05:00:00 +0500", "1970-01-01 04:59:59 +0500"})
+			},
+		},
+		{
+			name: "timestamp-string",
+			arr:  func() array.Interface { return newTimestamp(tsMs, []arrow.Timestamp{1500, 0, 86400000, -1}) },
+			to:   arrow.BinaryTypes.String,
+			want: func() array.Interface {
+				return newString([]string{"1970-01-01 00:00:01.500", "", "1970-01-02 00:00:00.000", "1969-12-31 23:59:59.999"})
+			},
+		},
+		{
+			name: "timestamp-string-format",
+			arr:  func() array.Interface { return newTimestamp(tsNY, []arrow.Timestamp{0, 0, 86400, 1e9}) },
+			to:   arrow.BinaryTypes.String,
+			opts: []compute.Option{compute.WithFormat("%d/%m/%Y %H:%M %Z")},
+			want: func() array.Interface {
+				return newString([]string{"31/12/1969 19:00 EST", "", "01/01/1970 19:00 EST", "08/09/2001 21:46 EDT"})
+			},
+		},
+		{
+			name: "string-timestamp",
+			arr: func() array.Interface {
+				return newString([]string{"1970-01-01 00:00:01.5", "", "1970-01-02 00:00:00", "1969-12-31 23:59:59.999"})
+			},
+			to:   tsMs,
+			want: func() array.Interface { return newTimestamp(tsMs, []arrow.Timestamp{1500, 0, 86400000, -1}) },
+		},
+		{
+			name: "timestamp-string-literals",
+			arr:  func() array.Interface { return newTimestamp(tsS, []arrow.Timestamp{1e9, 0, 0, 86400}) },
+			to:   arrow.BinaryTypes.String,
+			opts: []compute.Option{compute.WithFormat("Q1 %Y-%m-%d day 1, Mon PM MST 100%%")},
+			want: func() array.Interface {
+				return newString([]string{
+					"Q1 2001-09-09 day 1, Mon PM MST 100%", "",
+					"Q1 1970-01-01 day 1, Mon PM MST 100%",
+					"Q1 1970-01-02 day 1, Mon PM MST 100%",
+				})
+			},
+		},
+		{
+			name: "timestamp-string-directives",
+			arr:  func() array.Interface { return newTimestamp(tsMs, []arrow.Timestamp{1e12 + 5, 0, 0, -1}) },
+			to:   arrow.BinaryTypes.String,
+			opts: []compute.Option{compute.WithFormat("%a %A %e %b %B %y %j %I%p %T %z %Z")},
+			want: func() array.Interface {
+				return newString([]string{
+					"Sun Sunday  9 Sep September 01 252 01AM 01:46:40.005 +0000 UTC", "",
+					"Thu Thursday  1 Jan January 70 001 12AM 00:00:00.000 +0000 UTC",
+					"Wed Wednesday 31 Dec December 69 365 11PM 23:59:59.999 +0000 UTC",
+				})
+			},
+		},
+		{
+			name: "string-timestamp-directives",
+			arr: func() array.Interface {
+				return newString([]string{
+					"Sun 9 sep 2001 01:46:40.005AM +0000", "",
+					"Thu 1 JANUARY 1970 12:00:00PM -0130",
+					"Wed 31 Dec 1969 11:59:59.999PM +0000",
+				})
+			},
+			to:   tsMs,
+			opts: []compute.Option{compute.WithFormat("%a %e %b %Y %I:%M:%S%p %z")},
+			want: func() array.Interface {
+				return newTimestamp(tsMs, []arrow.Timestamp{1e12 + 5, 0, (12*3600 + 5400) * 1000, -1})
+			},
+		},
+		{
+			name: "string-timestamp-format",
+			arr: func() array.Interface {
+				return newString([]string{"31/12/1969 19:00", "", "01/01/1970 19:00", "08/09/2001 21:46"})
+			},
+			to:   tsNY,
+			opts: []compute.Option{compute.WithFormat("%d/%m/%Y %H:%M")},
+			want: func() array.Interface { return newTimestamp(tsNY, []arrow.Timestamp{0, 0, 86400, 999999960}) },
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			arr := tc.arr()
+			defer arr.Release()
+
+			want := tc.want()
+			defer want.Release()
+
+			got, err := compute.Cast(arr, tc.to, append(tc.opts, compute.WithAllocator(mem))...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer got.Release()
+
+			if got.DataType().ID() != want.DataType().ID() {
+				t.Fatalf("invalid type: got=%v, want=%v", got.DataType(), want.DataType())
+			}
+			if got.Len() != want.Len() || got.NullN() != want.NullN() {
+				t.Fatalf("invalid array: got=%v, want=%v", got, want)
+			}
+			for i := 0; i < got.Len(); i++ {
+				if got.IsValid(i) != want.IsValid(i) {
+					t.Fatalf("invalid validity at index %d", i)
+				}
+			}
+			if g, w := valuesOf(got), valuesOf(want); !reflect.DeepEqual(g, w) {
+				t.Fatalf("invalid values:\ngot= %v\nwant=%v", g, w)
+			}
+		})
+	}
+}
+
+func TestCastErrors(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	b := array.NewDate64Builder(mem)
+	defer b.Release()
+	b.AppendValues([]arrow.Date64{0, 1}, nil)
+	d64 := b.NewArray()
+	defer d64.Release()
+
+	_, err := compute.Cast(d64, arrow.FixedWidthTypes.Date32, compute.WithAllocator(mem))
+	if err == nil {
+		t.Fatalf("expected an error for a Date64 value that is not a whole number of days")
+	}
+
+	sb := array.NewStringBuilder(mem)
+	defer sb.Release()
+	sb.AppendValues([]string{"1970-01-01 00:00:00", "not a timestamp"}, nil)
+	str := sb.NewArray()
+	defer str.Release()
+
+	_, err = compute.Cast(str, &arrow.TimestampType{Unit: arrow.Second}, compute.WithAllocator(mem))
+	if err == nil {
+		t.Fatalf("expected an error for an invalid timestamp string")
+	}
+
+	_, err = compute.Cast(str, arrow.PrimitiveTypes.Int64, compute.WithAllocator(mem))
+	if err == nil {
+		t.Fatalf("expected an error for an unsupported cast")
+	}
+
+	tb := array.NewTimestampBuilder(mem, &arrow.TimestampType{Unit: arrow.Second})
+	defer tb.Release()
+	tb.AppendValues([]arrow.Timestamp{0}, nil)
+	ts := tb.NewArray()
+	defer ts.Release()
+
+	for _, format := range []string{"%Y-%m-%d %s", "%H:%M:%S.%f", "%Y%"} {
+		_, err = compute.Cast(ts, arrow.BinaryTypes.String, compute.WithAllocator(mem), compute.WithFormat(format))
+		if err == nil {
+			t.Fatalf("expected an error for the format %q", format)
+		}
+		_, err = compute.Cast(str, &arrow.TimestampType{Unit: arrow.Second}, compute.WithAllocator(mem), compute.WithFormat(format))
+		if err == nil {
+			t.Fatalf("expected an error for the format %q", format)
+		}
+	}
+
+	tsNs := &arrow.TimestampType{Unit: arrow.Nanosecond}
+
+	d32 := array.NewDate32Builder(mem)
+	defer d32.Release()
+	d32.AppendValues([]arrow.Date32{0, 200000}, nil)
+	dates := d32.NewArray()
+	defer dates.Release()
+
+	_, err = compute.Cast(dates, tsNs, compute.WithAllocator(mem))
+	if err == nil {
+		t.Fatalf("expected an error for a Date32 value overflowing a nanosecond timestamp")
+	}
+
+	sb.AppendValues([]string{"2300-01-01 00:00:00"}, nil)
+	late := sb.NewArray()
+	defer late.Release()
+
+	_, err = compute.Cast(late, tsNs, compute.WithAllocator(mem))
+	if err == nil {
+		t.Fatalf("expected an error for a string overflowing a nanosecond timestamp")
+	}
+
+	tb.AppendValues([]arrow.Timestamp{0, 1 << 50}, nil)
+	farTS := tb.NewArray()
+	defer farTS.Release()
+
+	_, err = compute.Cast(farTS, arrow.FixedWidthTypes.Date32, compute.WithAllocator(mem))
+	if err == nil {
+		t.Fatalf("expected an error for a timestamp overflowing a Date32")
+	}
+
+	b.AppendValues([]arrow.Date64{0, (1 << 33) * 86400000}, nil)
+	farD64 := b.NewArray()
+	defer farD64.Release()
+
+	_, err = compute.Cast(farD64, arrow.FixedWidthTypes.Date32, compute.WithAllocator(mem))
+	if err == nil {
+		t.Fatalf("expected an error for a Date64 value overflowing a Date32")
+	}
+
+	for _, tz := range []string{"+5", "+05:00:00", "Not/A_Zone"} {
+		_, err = compute.Cast(dates, &arrow.TimestampType{Unit: arrow.Second, TimeZone: tz}, compute.WithAllocator(mem))
+		if err == nil {
+			t.Fatalf("expected an error for the time zone %q", tz)
+		}
+	}
+}
+
+// valuesOf returns the valid values of arr.
+func valuesOf(arr array.Interface) []interface{} {
+	var o []interface{}
+	for i := 0; i < arr.Len(); i++ {
+		if arr.IsNull(i) {
+			o = append(o, nil)
+			continue
+		}
+		switch arr := arr.(type) {
+		case *array.Date32:
+			o = append(o, arr.Value(i))
+		case *array.Date64:
+			o = append(o, arr.Value(i))
+		case *array.Timestamp:
+			o = append(o, arr.Value(i))
+		case *array.String:
+			o = append(o, arr.Value(i))
+		}
+	}
+	return o
+}
@@ -0,0 +1,397 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute // import "github.com/apache/arrow/go/arrow/compute"
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// kDefaultFormat is the default format of timestamps.
+const kDefaultFormat = "%Y-%m-%d %H:%M:%S"
+
+// strftimeToken is a directive or a run of literal text of a strftime format.
+type strftimeToken struct {
+	verb byte   // directive, 0 for literal text.
+	lit  string // literal text.
+}
+
+// strftimeFormat is a parsed strftime-like format.
+type strftimeFormat []strftimeToken
+
+// parseStrftime parses the provided strftime-like format.
+// parseStrftime returns an error for unsupported directives.
+func parseStrftime(format string) (strftimeFormat, error) {
+	var (
+		toks strftimeFormat
+		lit  strings.Builder
+	)
+	flush := func() {
+		if lit.Len() > 0 {
+			toks = append(toks, strftimeToken{lit: lit.String()})
+			lit.Reset()
+		}
+	}
+	verb := func(c byte) {
+		flush()
+		toks = append(toks, strftimeToken{verb: c})
+	}
+
+	for i := 0; i < len(format); i++ {
+		c := format[i]
+		if c != '%' {
+			lit.WriteByte(c)
+			continue
+		}
+		if i+1 == len(format) {
+			return nil, errors.Errorf("arrow/compute: invalid format %q: trailing %%", format)
+		}
+		i++
+		switch c := format[i]; c {
+		case '%':
+			lit.WriteByte('%')
+		case 'F':
+			verb('Y')
+			lit.WriteByte('-')
+			verb('m')
+			lit.WriteByte('-')
+			verb('d')
+		case 'T':
+			verb('H')
+			lit.WriteByte(':')
+			verb('M')
+			lit.WriteByte(':')
+			verb('S')
+		case 'Y', 'y', 'm', 'b', 'B', 'd', 'e', 'j', 'a', 'A', 'H', 'I', 'p', 'M', 'S', 'z', 'Z':
+			verb(c)
+		default:
+			return nil, errors.Errorf("arrow/compute: invalid format %q: unsupported directive %%%c", format, c)
+		}
+	}
+	flush()
+
+	return toks, nil
+}
+
+// format formats t, with the provided number of fractional second digits.
+func (f strftimeFormat) format(t time.Time, digits int) string {
+	o := new(strings.Builder)
+	for _, tok := range f {
+		switch tok.verb {
+		case 0:
+			o.WriteString(tok.lit)
+		case 'Y':
+			fmt.Fprintf(o, "%04d", t.Year())
+		case 'y':
+			fmt.Fprintf(o, "%02d", t.Year()%100)
+		case 'm':
+			fmt.Fprintf(o, "%02d", int(t.Month()))
+		case 'b':
+			o.WriteString(t.Month().String()[:3])
+		case 'B':
+			o.WriteString(t.Month().String())
+		case 'd':
+			fmt.Fprintf(o, "%02d", t.Day())
+		case 'e':
+			fmt.Fprintf(o, "%2d", t.Day())
+		case 'j':
+			fmt.Fprintf(o, "%03d", t.YearDay())
+		case 'a':
+			o.WriteString(t.Weekday().String()[:3])
+		case 'A':
+			o.WriteString(t.Weekday().String())
+		case 'H':
+			fmt.Fprintf(o, "%02d", t.Hour())
+		case 'I':
+			h := t.Hour() % 12
+			if h == 0 {
+				h = 12
+			}
+			fmt.Fprintf(o, "%02d", h)
+		case 'p':
+			if t.Hour() < 12 {
+				o.WriteString("AM")
+			} else {
+				o.WriteString("PM")
+			}
+		case 'M':
+			fmt.Fprintf(o, "%02d", t.Minute())
+		case 'S':
+			fmt.Fprintf(o, "%02d", t.Second())
+			if digits > 0 {
+				frac := fmt.Sprintf("%09d", t.Nanosecond())
+				o.WriteString(".")
+				o.WriteString(frac[:digits])
+			}
+		case 'z':
+			_, off := t.Zone()
+			sign := '+'
+			if off < 0 {
+				sign = '-'
+				off = -off
+			}
+			fmt.Fprintf(o, "%c%02d%02d", sign, off/3600, off/60%60)
+		case 'Z':
+			name, _ := t.Zone()
+			o.WriteString(name)
+		}
+	}
+	return o.String()
+}
+
+// parse parses s according to the format, in the provided location.
+// Fractional seconds are accepted after the seconds.
+func (f strftimeFormat) parse(s string, loc *time.Location) (time.Time, error) {
+	var (
+		year         = 1970
+		month        = 1
+		day          = 1
+		yday         = -1
+		hour, min    int
+		sec, nsec    int
+		pm           = -1 // -1: no AM/PM, 0: AM, 1: PM
+		hour12       = false
+		zone         = loc
+		zname        string
+		err          error
+		str          = s
+		invalidValue = func(what string) error {
+			return errors.Errorf("arrow/compute: could not parse %q: invalid %s", str, what)
+		}
+	)
+
+	for _, tok := range f {
+		switch tok.verb {
+		case 0:
+			if !strings.HasPrefix(s, tok.lit) {
+				return time.Time{}, errors.Errorf("arrow/compute: could not parse %q: expected %q", str, tok.lit)
+			}
+			s = s[len(tok.lit):]
+		case 'Y':
+			year, s, err = parseInt(s, 4)
+			if err != nil {
+				return time.Time{}, invalidValue("year")
+			}
+		case 'y':
+			year, s, err = parseInt(s, 2)
+			if err != nil {
+				return time.Time{}, invalidValue("year")
+			}
+			// POSIX: 69-99 are in the 20th century, 00-68 in the 21st.
+			if year < 69 {
+				year += 2000
+			} else {
+				year += 1900
+			}
+		case 'm':
+			month, s, err = parseInt(s, 2)
+			if err != nil || month < 1 || month > 12 {
+				return time.Time{}, invalidValue("month")
+			}
+		case 'b', 'B':
+			var i int
+			i, s, err = parseName(s, monthNames)
+			if err != nil {
+				return time.Time{}, invalidValue("month name")
+			}
+			month = i + 1
+		case 'd', 'e':
+			if tok.verb == 'e' {
+				s = strings.TrimPrefix(s, " ")
+			}
+			day, s, err = parseInt(s, 2)
+			if err != nil || day < 1 || day > 31 {
+				return time.Time{}, invalidValue("day")
+			}
+		case 'j':
+			yday, s, err = parseInt(s, 3)
+			if err != nil || yday < 1 || yday > 366 {
+				return time.Time{}, invalidValue("day of the year")
+			}
+		case 'a', 'A':
+			_, s, err = parseName(s, weekdayNames)
+			if err != nil {
+				return time.Time{}, invalidValue("weekday name")
+			}
+		case 'H':
+			hour, s, err = parseInt(s, 2)
+			if err != nil || hour > 23 {
+				return time.Time{}, invalidValue("hour")
+			}
+		case 'I':
+			hour, s, err = parseInt(s, 2)
+			if err != nil || hour < 1 || hour > 12 {
+				return time.Time{}, invalidValue("hour")
+			}
+			hour12 = true
+		case 'p':
+			switch {
+			case len(s) >= 2 && strings.EqualFold(s[:2], "AM"):
+				pm = 0
+			case len(s) >= 2 && strings.EqualFold(s[:2], "PM"):
+				pm = 1
+			default:
+				return time.Time{}, invalidValue("AM/PM marker")
+			}
+			s = s[2:]
+		case 'M':
+			min, s, err = parseInt(s, 2)
+			if err != nil || min > 59 {
+				return time.Time{}, invalidValue("minute")
+			}
+		case 'S':
+			sec, s, err = parseInt(s, 2)
+			if err != nil || sec > 59 {
+				return time.Time{}, invalidValue("second")
+			}
+			if len(s) > 1 && s[0] == '.' && isDigit(s[1]) {
+				n := 1
+				for n < len(s) && isDigit(s[n]) {
+					n++
+				}
+				digits := s[1:n]
+				if len(digits) > 9 {
+					return time.Time{}, invalidValue("fractional second")
+				}
+				digits += strings.Repeat("0", 9-len(digits))
+				nsec, _, _ = parseInt(digits, 9)
+				s = s[n:]
+			}
+		case 'z':
+			var off int
+			off, s, err = parseOffset(s)
+			if err != nil {
+				return time.Time{}, invalidValue("UTC offset")
+			}
+			zone = time.FixedZone("", off)
+		case 'Z':
+			n := 0
+			for n < len(s) && (s[n] >= 'A' && s[n] <= 'Z' || s[n] >= 'a' && s[n] <= 'z') {
+				n++
+			}
+			if n == 0 {
+				return time.Time{}, invalidValue("time zone name")
+			}
+			zname, s = s[:n], s[n:]
+		}
+	}
+	if s != "" {
+		return time.Time{}, errors.Errorf("arrow/compute: could not parse %q: unexpected trailing text %q", str, s)
+	}
+
+	if hour12 || pm >= 0 {
+		if pm < 0 || !hour12 {
+			return time.Time{}, errors.Errorf("arrow/compute: could not parse %q: %%I and %%p must be used together", str)
+		}
+		hour = hour%12 + 12*pm
+	}
+
+	var t time.Time
+	switch {
+	case yday > 0:
+		t = time.Date(year, time.January, yday, hour, min, sec, nsec, zone)
+		if t.Year() != year {
+			return time.Time{}, invalidValue("day of the year")
+		}
+	default:
+		t = time.Date(year, time.Month(month), day, hour, min, sec, nsec, zone)
+		if t.Day() != day {
+			return time.Time{}, invalidValue("day")
+		}
+	}
+
+	if zname != "" {
+		switch name, _ := t.Zone(); {
+		case zname == name:
+		case zname == "UTC" || zname == "GMT" || zname == "Z":
+			t = time.Date(t.Year(), t.Month(), t.Day(), hour, min, sec, nsec, time.UTC)
+		default:
+			return time.Time{}, errors.Errorf("arrow/compute: could not parse %q: unknown time zone %q", str, zname)
+		}
+	}
+
+	return t, nil
+}
+
+var (
+	monthNames = []string{
+		"January", "February", "March", "April", "May", "June",
+		"July", "August", "September", "October", "November", "December",
+	}
+	weekdayNames = []string{
+		"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday",
+	}
+)
+
+func isDigit(c byte) bool { return '0' <= c && c <= '9' }
+
+// parseInt parses an unsigned integer of at most n digits at the start of s.
+func parseInt(s string, n int) (int, string, error) {
+	i, v := 0, 0
+	for i < n && i < len(s) && isDigit(s[i]) {
+		v = v*10 + int(s[i]-'0')
+		i++
+	}
+	if i == 0 {
+		return 0, s, errors.New("arrow/compute: expected a number")
+	}
+	return v, s[i:], nil
+}
+
+// parseName parses one of the provided names, or its 3-letter abbreviation,
+// at the start of s, ignoring case.
+func parseName(s string, names []string) (int, string, error) {
+	for _, n := range []int{0, 3} {
+		for i, name := range names {
+			if n > 0 {
+				name = name[:n]
+			}
+			if len(s) >= len(name) && strings.EqualFold(s[:len(name)], name) {
+				return i, s[len(name):], nil
+			}
+		}
+	}
+	return 0, s, errors.New("arrow/compute: unknown name")
+}
+
+// parseOffset parses a UTC offset (+hhmm, +hh:mm or Z) at the start of s,
+// and returns it in seconds.
+func parseOffset(s string) (int, string, error) {
+	if strings.HasPrefix(s, "Z") {
+		return 0, s[1:], nil
+	}
+	if len(s) < 5 || (s[0] != '+' && s[0] != '-') {
+		return 0, s, errors.New("arrow/compute: invalid UTC offset")
+	}
+	sign := 1
+	if s[0] == '-' {
+		sign = -1
+	}
+	hh, rest, err := parseInt(s[1:], 2)
+	if err != nil || len(s)-len(rest) != 3 {
+		return 0, s, errors.New("arrow/compute: invalid UTC offset")
+	}
+	rest = strings.TrimPrefix(rest, ":")
+	mm, tail, err := parseInt(rest, 2)
+	if err != nil || len(rest)-len(tail) != 2 || mm > 59 {
+		return 0, s, errors.New("arrow/compute: invalid UTC offset")
+	}
+	return sign * (hh*3600 + mm*60), tail, nil
+}
@@ -236,6 +236,7 @@ type FileWriter struct {
 	pw payloadWriter
 
 	schema *arrow.Schema
+	stats  []batchStats
 }
 
 // NewFileWriter opens an Arrow file using the provided writer w.
@@ -245,11 +246,17 @@ func NewFileWriter(w io.WriteSeeker, opts ...Option) (*FileWriter, error) {
 		err error
 	)
 
+	stats, err := newBatchStats(cfg.schema, cfg.stats)
+	if err != nil {
+		return nil, err
+	}
+
 	f := FileWriter{
 		w:      w,
 		pw:     &pwriter{w: w, schema: cfg.schema, pos: -1},
 		mem:    cfg.alloc,
 		schema: cfg.schema,
+		stats:  stats,
 	}
 
 	pos, err := f.w.Seek(0, io.SeekCurrent)
@@ -271,6 +278,13 @@ func (f *FileWriter) Close() error {
 		return nil
 	}
 
+	if pw, ok := f.pw.(*pwriter); ok && len(f.stats) > 0 {
+		pw.schema, err = withBatchStatistics(f.schema, f.stats)
+		if err != nil {
+			return err
+		}
+	}
+
 	err = f.pw.Close()
 	if err != nil {
 		return errors.Wrap(err, "arrow/ipc: could not close payload writer")
@@ -290,6 +304,15 @@ func (f *FileWriter) Write(rec array.Record) error {
 		return errors.Wrap(err, "arrow/ipc: could not write header")
 	}
 
+	for i := range f.stats {
+		if err := f.stats[i].check(rec); err != nil {
+			return err
+		}
+	}
+	for i := range f.stats {
+		f.stats[i].update(rec)
+	}
+
 	const allow64b = true
 	var (
 		data = payload{msg: MessageRecordBatch}
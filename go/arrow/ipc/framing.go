@@ -0,0 +1,395 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipc // import "github.com/apache/arrow/go/arrow/ipc"
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+	"math"
+	"sync/atomic"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/internal/debug"
+	"github.com/pkg/errors"
+)
+
+// encodeBase64Frame returns the base64 encoding of msg, terminated by a newline.
+func encodeBase64Frame(msg []byte) []byte {
+	n := base64.StdEncoding.EncodedLen(len(msg))
+	frame := make([]byte, n+1)
+	base64.StdEncoding.Encode(frame, msg)
+	frame[n] = '\n'
+	return frame
+}
+
+// base64FrameReader decodes a stream of base64-encoded, newline-terminated,
+// IPC messages.
+type base64FrameReader struct {
+	r   *bufio.Reader
+	buf bytes.Buffer // decoded bytes not yet consumed.
+	err error
+}
+
+// NewBase64FrameReader returns a reader that decodes an IPC stream written
+// with the WithBase64Framing option.
+// The returned reader can be passed to NewReader.
+func NewBase64FrameReader(r io.Reader) io.Reader {
+	return &base64FrameReader{r: bufio.NewReader(r)}
+}
+
+func (r *base64FrameReader) Read(p []byte) (int, error) {
+	for r.buf.Len() == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		r.err = r.next()
+	}
+	return r.buf.Read(p)
+}
+
+// next decodes the next frame from the underlying reader.
+func (r *base64FrameReader) next() error {
+	line, err := r.r.ReadBytes('\n')
+	if err != nil && err != io.EOF {
+		return err
+	}
+	if err == io.EOF && len(line) == 0 {
+		return io.EOF
+	}
+	line = bytes.TrimRight(line, "\r\n")
+	msg := make([]byte, base64.StdEncoding.DecodedLen(len(line)))
+	n, derr := base64.StdEncoding.Decode(msg, line)
+	if derr != nil {
+		return errors.Wrap(derr, "arrow/ipc: could not decode base64 frame")
+	}
+	r.buf.Write(msg[:n])
+	return err
+}
+
+// Checksummed frames have the following layout:
+//
+//	sync  [4]byte     kFrameSync marker
+//	seq   uint64      sequence number of the message in the stream
+//	size  uint32      size of the payload
+//	hcrc  uint32      CRC-32C of the seq and size fields
+//	data  [size]byte  payload (a complete IPC message)
+//	dcrc  uint32      CRC-32C of the payload
+//
+// All integers are little-endian.
+// The schema message is framed with sequence number 0, the i-th record batch
+// with sequence number i+1, and the end-of-stream message comes last.
+// A stream resumed with WithFirstBatchIndex(n) starts with the schema message,
+// followed by the n-th record batch, with sequence number n+1.
+const (
+	kFrameHeaderSize  = 20
+	kFrameTrailerSize = 4
+	kFrameMaxSize     = math.MaxInt32
+	kFrameBufferSize  = 64 << 10 // minimum size of the read buffer of a CheckedReader.
+)
+
+var (
+	kFrameSync  = [4]byte{'A', 'R', 'W', 0xFE}
+	kFrameTable = crc32.MakeTable(crc32.Castagnoli)
+)
+
+// encodeChecksumFrame returns msg wrapped in a checksummed frame.
+func encodeChecksumFrame(seq uint64, msg []byte) []byte {
+	frame := make([]byte, kFrameHeaderSize+len(msg)+kFrameTrailerSize)
+	copy(frame, kFrameSync[:])
+	binary.LittleEndian.PutUint64(frame[4:], seq)
+	binary.LittleEndian.PutUint32(frame[12:], uint32(len(msg)))
+	binary.LittleEndian.PutUint32(frame[16:], crc32.Checksum(frame[4:16], kFrameTable))
+	copy(frame[kFrameHeaderSize:], msg)
+	binary.LittleEndian.PutUint32(frame[kFrameHeaderSize+len(msg):], crc32.Checksum(msg, kFrameTable))
+	return frame
+}
+
+// CheckedReader reads records from a stream written with the
+// WithChecksumFraming option.
+//
+// Contrary to Reader, CheckedReader does not stop at the first corrupted
+// message: it skips corrupted frames, resynchronizes on the next valid
+// frame and keeps track of the record batches that were lost.
+type CheckedReader struct {
+	refCount int64
+
+	r      io.Reader
+	buf    []byte // bytes read from r and not yet consumed.
+	rerr   error  // error returned by the last read from r.
+	schema *arrow.Schema
+
+	seq  uint64  // sequence number of the next expected frame.
+	last int64   // index of the last record batch successfully read.
+	lost []int64 // indices of the record batches lost to corruption.
+
+	rec  array.Record
+	err  error
+	done bool
+}
+
+// NewCheckedReader returns a reader that reads records from a checksummed
+// input stream.
+// The index of the first record batch of the stream can be specified with
+// WithFirstBatchIndex, when reading a stream resumed after an interruption.
+//
+// NewCheckedReader returns an error if the schema message can not be read.
+func NewCheckedReader(r io.Reader, opts ...Option) (*CheckedReader, error) {
+	cfg := newConfig(opts...)
+
+	cr := &CheckedReader{
+		refCount: 1,
+		r:        r,
+		last:     cfg.frame.first - 1,
+	}
+
+	seq, msg, err := cr.frame()
+	if err != nil {
+		return nil, errors.Wrap(err, "arrow/ipc: could not read schema frame")
+	}
+	if seq != 0 {
+		return nil, errors.Errorf("arrow/ipc: invalid schema frame sequence number (got=%d, want=0)", seq)
+	}
+	cr.seq = uint64(cfg.frame.first) + 1
+
+	sr, err := NewReader(bytes.NewReader(msg), opts...)
+	if err != nil {
+		return nil, err
+	}
+	cr.schema = sr.Schema()
+	sr.Release()
+
+	return cr, nil
+}
+
+// Retain increases the reference count by 1.
+// Retain may be called simultaneously from multiple goroutines.
+func (r *CheckedReader) Retain() {
+	atomic.AddInt64(&r.refCount, 1)
+}
+
+// Release decreases the reference count by 1.
+// When the reference count goes to zero, the memory is freed.
+// Release may be called simultaneously from multiple goroutines.
+func (r *CheckedReader) Release() {
+	debug.Assert(atomic.LoadInt64(&r.refCount) > 0, "too many releases")
+
+	if atomic.AddInt64(&r.refCount, -1) == 0 {
+		if r.rec != nil {
+			r.rec.Release()
+			r.rec = nil
+		}
+		r.buf = nil
+	}
+}
+
+func (r *CheckedReader) Schema() *arrow.Schema { return r.schema }
+
+// Err returns the last error encountered during the iteration over the
+// underlying stream.
+// Err returns io.ErrUnexpectedEOF if the stream ended without an
+// end-of-stream message.
+func (r *CheckedReader) Err() error { return r.err }
+
+// LastGood returns the index of the last record batch successfully read
+// from the underlying stream, or the index of the first record batch minus
+// one if none was read.
+// A sender can resume the transmission of a stream after that record batch,
+// with WithFirstBatchIndex(LastGood()+1).
+func (r *CheckedReader) LastGood() int64 { return r.last }
+
+// Lost returns the indices of the record batches that were lost to
+// corruption so far.
+// A lost record batch is detected when a later frame is successfully read:
+// the record batches lost at the end of a truncated stream are not reported.
+func (r *CheckedReader) Lost() []int64 { return r.lost }
+
+// Next returns whether a Record could be extracted from the underlying stream.
+func (r *CheckedReader) Next() bool {
+	if r.rec != nil {
+		r.rec.Release()
+		r.rec = nil
+	}
+
+	if r.err != nil || r.done {
+		return false
+	}
+
+	for {
+		seq, msg, err := r.frame()
+		switch {
+		case err == io.EOF:
+			r.err = io.ErrUnexpectedEOF
+			return false
+		case err != nil:
+			r.err = err
+			return false
+		}
+
+		if seq < r.seq {
+			// duplicate frame.
+			continue
+		}
+		for ; r.seq < seq; r.seq++ {
+			r.lost = append(r.lost, int64(r.seq-1))
+		}
+		r.seq = seq + 1
+
+		switch {
+		case r.next(seq, msg):
+			return true
+		case r.done || r.err != nil:
+			return false
+		}
+	}
+}
+
+// next decodes the record batch held by the payload of the seq-th frame.
+func (r *CheckedReader) next(seq uint64, msg []byte) bool {
+	mr := NewMessageReader(bytes.NewReader(msg))
+	defer mr.Release()
+
+	m, err := mr.Message()
+	switch {
+	case err == io.EOF:
+		r.done = true
+		return false
+	case err != nil:
+		r.lost = append(r.lost, int64(seq-1))
+		return false
+	}
+
+	if got, want := m.Type(), MessageRecordBatch; got != want {
+		r.err = errors.Errorf("arrow/ipc: invalid message type (got=%v, want=%v)", got, want)
+		return false
+	}
+
+	r.rec = newRecord(r.schema, m.meta, bytes.NewReader(m.body.Bytes()))
+	r.last = int64(seq - 1)
+	return true
+}
+
+// Record returns the current record that has been extracted from the
+// underlying stream.
+// It is valid until the next call to Next.
+func (r *CheckedReader) Record() array.Record {
+	return r.rec
+}
+
+// frame reads the next valid frame from the underlying stream.
+//
+// Corrupted or truncated frames are skipped: frame then resumes the search
+// for a sync marker right after the start of the invalid frame, so that
+// frames following a frame that lost some bytes are not lost as well.
+func (r *CheckedReader) frame() (uint64, []byte, error) {
+	for {
+		ok, err := r.fill(kFrameHeaderSize)
+		if err != nil {
+			return 0, nil, err
+		}
+		if !ok {
+			return 0, nil, io.EOF
+		}
+
+		hdr := r.buf[:kFrameHeaderSize]
+		if !bytes.Equal(hdr[:4], kFrameSync[:]) ||
+			binary.LittleEndian.Uint32(hdr[16:]) != crc32.Checksum(hdr[4:16], kFrameTable) {
+			r.resync()
+			continue
+		}
+
+		var (
+			seq  = binary.LittleEndian.Uint64(hdr[4:])
+			size = int(binary.LittleEndian.Uint32(hdr[12:]))
+		)
+		if size > kFrameMaxSize {
+			r.resync()
+			continue
+		}
+
+		n := kFrameHeaderSize + size + kFrameTrailerSize
+		ok, err = r.fill(n)
+		if err != nil {
+			return 0, nil, err
+		}
+		if !ok {
+			// truncated frame.
+			r.resync()
+			continue
+		}
+
+		msg := r.buf[kFrameHeaderSize : kFrameHeaderSize+size]
+		if binary.LittleEndian.Uint32(r.buf[kFrameHeaderSize+size:]) != crc32.Checksum(msg, kFrameTable) {
+			r.resync()
+			continue
+		}
+
+		r.buf = r.buf[n:]
+		return seq, msg, nil
+	}
+}
+
+// fill reads from the underlying stream until at least n bytes are buffered.
+// fill returns false if the underlying stream ended before.
+func (r *CheckedReader) fill(n int) (bool, error) {
+	for len(r.buf) < n {
+		switch r.rerr {
+		case nil:
+		case io.EOF:
+			return false, nil
+		default:
+			return false, r.rerr
+		}
+
+		if cap(r.buf) < n {
+			size := 2 * cap(r.buf)
+			if size < n {
+				size = n
+			}
+			if size < kFrameBufferSize {
+				size = kFrameBufferSize
+			}
+			buf := make([]byte, len(r.buf), size)
+			copy(buf, r.buf)
+			r.buf = buf
+		}
+
+		var m int
+		m, r.rerr = r.r.Read(r.buf[len(r.buf):cap(r.buf)])
+		r.buf = r.buf[:len(r.buf)+m]
+	}
+	return true, nil
+}
+
+// resync discards the first buffered byte and the following bytes up to the
+// next candidate sync marker.
+func (r *CheckedReader) resync() {
+	i := bytes.IndexByte(r.buf[1:], kFrameSync[0])
+	if i < 0 {
+		r.buf = r.buf[len(r.buf):]
+		return
+	}
+	r.buf = r.buf[1+i:]
+}
+
+var (
+	_ array.RecordReader = (*CheckedReader)(nil)
+)
@@ -0,0 +1,257 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipc_test
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/ipc"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+func TestCheckedReader(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema([]arrow.Field{{Name: "v", Type: arrow.PrimitiveTypes.Int64}}, nil)
+
+	// frames returns the checksummed stream, and the offsets of the frames
+	// of its record batches.
+	// The frame of the first record batch is preceded by the schema frame.
+	frames := func() ([]byte, []int) {
+		var (
+			buf  = new(bytes.Buffer)
+			offs []int
+			w    = ipc.NewWriter(buf, ipc.WithSchema(schema), ipc.WithAllocator(mem), ipc.WithChecksumFraming())
+			b    = array.NewInt64Builder(mem)
+		)
+		defer b.Release()
+
+		for i := 0; i < 5; i++ {
+			b.AppendValues([]int64{int64(i), int64(i)}, nil)
+			arr := b.NewArray()
+			rec := array.NewRecord(schema, []array.Interface{arr}, -1)
+			arr.Release()
+
+			offs = append(offs, buf.Len())
+			err := w.Write(rec)
+			rec.Release()
+			if err != nil {
+				t.Fatalf("could not write record: %+v", err)
+			}
+		}
+		offs = append(offs, buf.Len())
+		err := w.Close()
+		if err != nil {
+			t.Fatalf("could not close writer: %+v", err)
+		}
+		return buf.Bytes(), offs
+	}
+
+	read := func(raw []byte) (vs []int64, last int64, lost []int64, err error) {
+		r, err := ipc.NewCheckedReader(bytes.NewReader(raw), ipc.WithSchema(schema), ipc.WithAllocator(mem))
+		if err != nil {
+			return nil, 0, nil, err
+		}
+		defer r.Release()
+
+		for r.Next() {
+			vs = append(vs, r.Record().Column(0).(*array.Int64).Value(0))
+		}
+		return vs, r.LastGood(), r.Lost(), r.Err()
+	}
+
+	for _, tc := range []struct {
+		name    string
+		corrupt func(raw []byte, offs []int) []byte
+		vs      []int64
+		last    int64
+		lost    []int64
+		err     error
+	}{
+		{
+			name:    "valid",
+			corrupt: func(raw []byte, offs []int) []byte { return raw },
+			vs:      []int64{0, 1, 2, 3, 4},
+			last:    4,
+		},
+		{
+			name: "corrupted-payload",
+			corrupt: func(raw []byte, offs []int) []byte {
+				raw[offs[1]+30] ^= 0xff
+				return raw
+			},
+			vs:   []int64{0, 2, 3, 4},
+			last: 4,
+			lost: []int64{1},
+		},
+		{
+			name: "corrupted-header",
+			corrupt: func(raw []byte, offs []int) []byte {
+				raw[offs[2]+6] ^= 0xff
+				raw[offs[3]+14] ^= 0xff
+				return raw
+			},
+			vs:   []int64{0, 1, 4},
+			last: 4,
+			lost: []int64{2, 3},
+		},
+		{
+			name: "garbage",
+			corrupt: func(raw []byte, offs []int) []byte {
+				o := append([]byte(nil), raw[:offs[1]]...)
+				o = append(o, []byte("ARW\xfe garbage ARW")...)
+				o = append(o, raw[offs[1]:]...)
+				return o
+			},
+			vs:   []int64{0, 1, 2, 3, 4},
+			last: 4,
+		},
+		{
+			name: "dropped-bytes",
+			corrupt: func(raw []byte, offs []int) []byte {
+				o := append([]byte(nil), raw[:offs[1]+30]...)
+				o = append(o, raw[offs[1]+38:]...)
+				return o
+			},
+			vs:   []int64{0, 2, 3, 4},
+			last: 4,
+			lost: []int64{1},
+		},
+		{
+			name: "corrupted-eos",
+			corrupt: func(raw []byte, offs []int) []byte {
+				raw[offs[5]+22] ^= 0xff
+				return raw
+			},
+			vs:   []int64{0, 1, 2, 3, 4},
+			last: 4,
+			err:  io.ErrUnexpectedEOF,
+		},
+		{
+			name: "truncated",
+			corrupt: func(raw []byte, offs []int) []byte {
+				return raw[:offs[4]+10]
+			},
+			vs:   []int64{0, 1, 2, 3},
+			last: 3,
+			err:  io.ErrUnexpectedEOF,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			raw, offs := frames()
+			vs, last, lost, err := read(tc.corrupt(raw, offs))
+			if err != tc.err {
+				t.Fatalf("invalid error: got=%v, want=%v", err, tc.err)
+			}
+			if !reflect.DeepEqual(vs, tc.vs) {
+				t.Fatalf("invalid values: got=%v, want=%v", vs, tc.vs)
+			}
+			if last != tc.last {
+				t.Fatalf("invalid last good batch: got=%d, want=%d", last, tc.last)
+			}
+			if !reflect.DeepEqual(lost, tc.lost) {
+				t.Fatalf("invalid lost batches: got=%v, want=%v", lost, tc.lost)
+			}
+		})
+	}
+}
+
+func TestCheckedReaderResume(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema([]arrow.Field{{Name: "v", Type: arrow.PrimitiveTypes.Int64}}, nil)
+
+	// the sender resumes the transmission of a stream after its 3rd record batch.
+	const first = 3
+
+	buf := new(bytes.Buffer)
+	w := ipc.NewWriter(
+		buf,
+		ipc.WithSchema(schema), ipc.WithAllocator(mem),
+		ipc.WithChecksumFraming(), ipc.WithFirstBatchIndex(first),
+	)
+
+	b := array.NewInt64Builder(mem)
+	defer b.Release()
+
+	for i := first; i < 5; i++ {
+		b.AppendValues([]int64{int64(i)}, nil)
+		arr := b.NewArray()
+		rec := array.NewRecord(schema, []array.Interface{arr}, -1)
+		arr.Release()
+
+		err := w.Write(rec)
+		rec.Release()
+		if err != nil {
+			t.Fatalf("could not write record: %+v", err)
+		}
+	}
+	err := w.Close()
+	if err != nil {
+		t.Fatalf("could not close writer: %+v", err)
+	}
+
+	for _, tc := range []struct {
+		name  string
+		first int64
+		vs    []int64
+		lost  []int64
+	}{
+		{name: "resumed", first: first, vs: []int64{3, 4}},
+		{name: "from-start", first: 0, vs: []int64{3, 4}, lost: []int64{0, 1, 2}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := ipc.NewCheckedReader(
+				bytes.NewReader(buf.Bytes()),
+				ipc.WithSchema(schema), ipc.WithAllocator(mem),
+				ipc.WithFirstBatchIndex(tc.first),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Release()
+
+			if got, want := r.LastGood(), tc.first-1; got != want {
+				t.Fatalf("invalid last good batch: got=%d, want=%d", got, want)
+			}
+
+			var vs []int64
+			for r.Next() {
+				vs = append(vs, r.Record().Column(0).(*array.Int64).Value(0))
+			}
+			if err := r.Err(); err != nil {
+				t.Fatalf("could not read stream: %+v", err)
+			}
+			if !reflect.DeepEqual(vs, tc.vs) {
+				t.Fatalf("invalid values: got=%v, want=%v", vs, tc.vs)
+			}
+			if got, want := r.LastGood(), int64(4); got != want {
+				t.Fatalf("invalid last good batch: got=%d, want=%d", got, want)
+			}
+			if !reflect.DeepEqual(r.Lost(), tc.lost) {
+				t.Fatalf("invalid lost batches: got=%v, want=%v", r.Lost(), tc.lost)
+			}
+		})
+	}
+}
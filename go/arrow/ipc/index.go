@@ -0,0 +1,489 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipc // import "github.com/apache/arrow/go/arrow/ipc"
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/pkg/errors"
+)
+
+// kStatsMetadataPrefix is the prefix of the schema metadata keys under which
+// the batch statistics of a field are stored in the footer of an Arrow file.
+const kStatsMetadataPrefix = "ARROW:go:stats:"
+
+// ErrKeyNotFound is returned by FileIndex when no row matches the requested key.
+var ErrKeyNotFound = errors.New("arrow/ipc: key not found")
+
+// batchStats holds the minimum and maximum values of a field, for each
+// record batch written to a file.
+type batchStats struct {
+	name   string
+	col    int
+	ranges [][]string  // [min, max] of each record batch. nil for empty batches.
+	last   interface{} // maximum value of the last non-empty record batch.
+}
+
+func newBatchStats(schema *arrow.Schema, names []string) ([]batchStats, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	if schema == nil {
+		return nil, errors.Errorf("arrow/ipc: batch statistics require a schema")
+	}
+
+	stats := make([]batchStats, len(names))
+	for i, name := range names {
+		col := schema.FieldIndex(name)
+		if col < 0 {
+			return nil, errors.Errorf("arrow/ipc: no field %q for batch statistics", name)
+		}
+		if dt := schema.Field(col).Type; !isKeyType(dt) {
+			return nil, errors.Errorf("arrow/ipc: batch statistics not supported for field %q (type=%v)", name, dt)
+		}
+		stats[i] = batchStats{name: name, col: col}
+	}
+	return stats, nil
+}
+
+// check verifies that the values of the field in the provided record batch
+// are sorted in ascending order, and not less than the values of the
+// previous record batches.
+func (st *batchStats) check(rec array.Record) error {
+	arr := rec.Column(st.col)
+	if arr.NullN() > 0 {
+		return errors.Errorf("arrow/ipc: batch statistics field %q has null values", st.name)
+	}
+
+	prev := st.last
+	for i := 0; i < arr.Len(); i++ {
+		v := keyValue(arr, i)
+		if prev != nil && compareKeys(v, prev) < 0 {
+			return errors.Errorf(
+				"arrow/ipc: batch statistics field %q is not sorted in ascending order (record batch %d, row %d)",
+				st.name, len(st.ranges), i,
+			)
+		}
+		prev = v
+	}
+	return nil
+}
+
+// update computes the statistics of the provided record batch.
+// update must be called after a successful check of the record batch.
+func (st *batchStats) update(rec array.Record) {
+	arr := rec.Column(st.col)
+	if arr.Len() == 0 {
+		st.ranges = append(st.ranges, nil)
+		return
+	}
+
+	min := keyValue(arr, 0)
+	max := keyValue(arr, arr.Len()-1)
+	st.ranges = append(st.ranges, []string{formatKey(min), formatKey(max)})
+	st.last = max
+}
+
+// withBatchStatistics returns a copy of the schema whose metadata holds the
+// provided batch statistics.
+func withBatchStatistics(schema *arrow.Schema, stats []batchStats) (*arrow.Schema, error) {
+	var (
+		md   = schema.Metadata()
+		keys = append([]string(nil), md.Keys()...)
+		vals = append([]string(nil), md.Values()...)
+	)
+
+	for _, st := range stats {
+		v, err := json.Marshal(st.ranges)
+		if err != nil {
+			return nil, errors.Wrapf(err, "arrow/ipc: could not encode batch statistics of field %q", st.name)
+		}
+		keys = append(keys, kStatsMetadataPrefix+st.name)
+		vals = append(vals, string(v))
+	}
+
+	meta := arrow.NewMetadata(keys, vals)
+	return arrow.NewSchema(schema.Fields(), &meta), nil
+}
+
+// FileIndex provides random access to the rows of an Arrow file, through a
+// key column sorted in ascending order.
+//
+// FileIndex relies on the batch statistics stored in the file footer
+// (see WithBatchStatistics) to locate the record batches holding a key,
+// and only reads these record batches from the file.
+type FileIndex struct {
+	f   *FileReader
+	col int
+	dt  arrow.DataType
+
+	batches []int         // indices of the non-empty record batches.
+	mins    []interface{} // minimum key of each non-empty record batch.
+	maxs    []interface{} // maximum key of each non-empty record batch.
+}
+
+// NewFileIndex returns an index over the provided file, using the named
+// field as the key column.
+//
+// NewFileIndex returns an error if the file holds no batch statistics for
+// the key column.
+func NewFileIndex(f *FileReader, key string) (*FileIndex, error) {
+	schema := f.Schema()
+	col := schema.FieldIndex(key)
+	if col < 0 {
+		return nil, errors.Errorf("arrow/ipc: no key field %q in schema", key)
+	}
+
+	md := schema.Metadata()
+	i := md.FindKey(kStatsMetadataPrefix + key)
+	if i < 0 {
+		return nil, errors.Errorf("arrow/ipc: no batch statistics for key field %q", key)
+	}
+
+	var ranges [][]string
+	err := json.Unmarshal([]byte(md.Values()[i]), &ranges)
+	if err != nil {
+		return nil, errors.Wrapf(err, "arrow/ipc: could not decode batch statistics for key field %q", key)
+	}
+	if len(ranges) != f.NumRecords() {
+		return nil, errors.Errorf(
+			"arrow/ipc: inconsistent batch statistics for key field %q (got=%d, want=%d)",
+			key, len(ranges), f.NumRecords(),
+		)
+	}
+
+	idx := &FileIndex{
+		f:   f,
+		col: col,
+		dt:  schema.Field(col).Type,
+	}
+
+	for i, rng := range ranges {
+		if rng == nil {
+			continue
+		}
+		if len(rng) != 2 {
+			return nil, errors.Errorf("arrow/ipc: invalid batch statistics for record batch %d", i)
+		}
+		min, err := parseKey(idx.dt, rng[0])
+		if err != nil {
+			return nil, errors.Wrapf(err, "arrow/ipc: could not decode minimum key of record batch %d", i)
+		}
+		max, err := parseKey(idx.dt, rng[1])
+		if err != nil {
+			return nil, errors.Wrapf(err, "arrow/ipc: could not decode maximum key of record batch %d", i)
+		}
+		idx.batches = append(idx.batches, i)
+		idx.mins = append(idx.mins, min)
+		idx.maxs = append(idx.maxs, max)
+	}
+
+	return idx, nil
+}
+
+// Lookup returns the first row whose key is equal to the provided value,
+// as a one-row record.
+// Lookup returns ErrKeyNotFound if there is no such row.
+//
+// The returned record must be Release()'d after use.
+func (idx *FileIndex) Lookup(key interface{}) (array.Record, error) {
+	k, err := normKey(idx.dt, key)
+	if err != nil {
+		return nil, err
+	}
+
+	b, row, rec, err := idx.lowerBound(k)
+	if err != nil {
+		return nil, err
+	}
+	if b == len(idx.batches) {
+		return nil, ErrKeyNotFound
+	}
+
+	if rec == nil {
+		rec, err = idx.record(b)
+		if err != nil {
+			return nil, err
+		}
+	}
+	defer rec.Release()
+
+	if compareKeys(keyValue(rec.Column(idx.col), row), k) != 0 {
+		return nil, ErrKeyNotFound
+	}
+
+	return rec.NewSlice(int64(row), int64(row+1)), nil
+}
+
+// LookupRange returns the rows whose key is in the [lo, hi) range, as
+// slices of the record batches of the file.
+// LookupRange returns no record if there is no such row.
+//
+// The returned records must be Release()'d after use.
+func (idx *FileIndex) LookupRange(lo, hi interface{}) ([]array.Record, error) {
+	klo, err := normKey(idx.dt, lo)
+	if err != nil {
+		return nil, err
+	}
+	khi, err := normKey(idx.dt, hi)
+	if err != nil {
+		return nil, err
+	}
+	if compareKeys(klo, khi) >= 0 {
+		return nil, nil
+	}
+
+	b0, r0, rec0, err := idx.lowerBound(klo)
+	if err != nil {
+		return nil, err
+	}
+	if rec0 != nil {
+		defer rec0.Release()
+	}
+
+	b1, r1, rec1, err := idx.lowerBound(khi)
+	if err != nil {
+		return nil, err
+	}
+	if rec1 != nil {
+		defer rec1.Release()
+	}
+
+	var recs []array.Record
+	for b := b0; b <= b1 && b < len(idx.batches); b++ {
+		if b == b1 && r1 == 0 {
+			break
+		}
+
+		var rec array.Record
+		switch {
+		case b == b0 && rec0 != nil:
+			rec = rec0
+		case b == b1 && rec1 != nil:
+			rec = rec1
+		default:
+			rec, err = idx.record(b)
+			if err != nil {
+				for _, rec := range recs {
+					rec.Release()
+				}
+				return nil, err
+			}
+			defer rec.Release()
+		}
+
+		var (
+			beg = int64(0)
+			end = rec.NumRows()
+		)
+		if b == b0 {
+			beg = int64(r0)
+		}
+		if b == b1 {
+			end = int64(r1)
+		}
+		recs = append(recs, rec.NewSlice(beg, end))
+	}
+
+	return recs, nil
+}
+
+// lowerBound returns the position (index of the non-empty record batch,
+// row in that record batch) of the first row whose key is not less than k.
+// lowerBound returns len(idx.batches) if there is no such row.
+//
+// If lowerBound had to read the record batch to locate the row, that record
+// batch is also returned and must be Release()'d after use.
+func (idx *FileIndex) lowerBound(k interface{}) (int, int, array.Record, error) {
+	b := sort.Search(len(idx.batches), func(i int) bool {
+		return compareKeys(idx.maxs[i], k) >= 0
+	})
+	if b == len(idx.batches) || compareKeys(idx.mins[b], k) >= 0 {
+		return b, 0, nil, nil
+	}
+
+	rec, err := idx.record(b)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+	arr := rec.Column(idx.col)
+	row := sort.Search(arr.Len(), func(i int) bool {
+		return compareKeys(keyValue(arr, i), k) >= 0
+	})
+	return b, row, rec, nil
+}
+
+// record reads the b-th non-empty record batch from the file.
+// The returned record must be Release()'d after use.
+func (idx *FileIndex) record(b int) (array.Record, error) {
+	rec, err := idx.f.Record(idx.batches[b])
+	if err != nil {
+		return nil, errors.Wrapf(err, "arrow/ipc: could not read record batch %d", idx.batches[b])
+	}
+	if rec.Column(idx.col).NullN() > 0 {
+		return nil, errors.Errorf("arrow/ipc: key field of record batch %d has null values", idx.batches[b])
+	}
+	rec.Retain()
+	return rec, nil
+}
+
+// isKeyType returns whether values of the provided data type can be used as keys.
+func isKeyType(dt arrow.DataType) bool {
+	switch dt.ID() {
+	case arrow.INT8, arrow.INT16, arrow.INT32, arrow.INT64,
+		arrow.UINT8, arrow.UINT16, arrow.UINT32, arrow.UINT64,
+		arrow.FLOAT32, arrow.FLOAT64,
+		arrow.STRING:
+		return true
+	}
+	return false
+}
+
+// keyValue returns the i-th value of arr as a key.
+// Keys are represented as int64, uint64, float64 or string values.
+func keyValue(arr array.Interface, i int) interface{} {
+	switch arr := arr.(type) {
+	case *array.Int8:
+		return int64(arr.Value(i))
+	case *array.Int16:
+		return int64(arr.Value(i))
+	case *array.Int32:
+		return int64(arr.Value(i))
+	case *array.Int64:
+		return arr.Value(i)
+	case *array.Uint8:
+		return uint64(arr.Value(i))
+	case *array.Uint16:
+		return uint64(arr.Value(i))
+	case *array.Uint32:
+		return uint64(arr.Value(i))
+	case *array.Uint64:
+		return arr.Value(i)
+	case *array.Float32:
+		return float64(arr.Value(i))
+	case *array.Float64:
+		return arr.Value(i)
+	case *array.String:
+		return arr.Value(i)
+	default:
+		panic(errors.Errorf("arrow/ipc: invalid key array type %T", arr))
+	}
+}
+
+// normKey converts the provided Go value to a key, for the provided data type.
+func normKey(dt arrow.DataType, v interface{}) (interface{}, error) {
+	rv := reflect.ValueOf(v)
+	switch dt.ID() {
+	case arrow.INT8, arrow.INT16, arrow.INT32, arrow.INT64:
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return rv.Int(), nil
+		}
+	case arrow.UINT8, arrow.UINT16, arrow.UINT32, arrow.UINT64:
+		switch rv.Kind() {
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return rv.Uint(), nil
+		}
+	case arrow.FLOAT32, arrow.FLOAT64:
+		switch rv.Kind() {
+		case reflect.Float32, reflect.Float64:
+			return rv.Float(), nil
+		}
+	case arrow.STRING:
+		if rv.Kind() == reflect.String {
+			return rv.String(), nil
+		}
+	}
+	return nil, errors.Errorf("arrow/ipc: invalid key value %v (%T) for key type %v", v, v, dt)
+}
+
+// compareKeys returns -1, 0 or +1 depending on whether a is less than,
+// equal to or greater than b.
+func compareKeys(a, b interface{}) int {
+	switch a := a.(type) {
+	case int64:
+		b := b.(int64)
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return +1
+		}
+	case uint64:
+		b := b.(uint64)
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return +1
+		}
+	case float64:
+		b := b.(float64)
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return +1
+		}
+	case string:
+		b := b.(string)
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return +1
+		}
+	default:
+		panic(errors.Errorf("arrow/ipc: invalid key type %T", a))
+	}
+	return 0
+}
+
+func formatKey(v interface{}) string {
+	switch v := v.(type) {
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case uint64:
+		return strconv.FormatUint(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case string:
+		return v
+	default:
+		panic(errors.Errorf("arrow/ipc: invalid key type %T", v))
+	}
+}
+
+func parseKey(dt arrow.DataType, s string) (interface{}, error) {
+	switch dt.ID() {
+	case arrow.INT8, arrow.INT16, arrow.INT32, arrow.INT64:
+		return strconv.ParseInt(s, 10, 64)
+	case arrow.UINT8, arrow.UINT16, arrow.UINT32, arrow.UINT64:
+		return strconv.ParseUint(s, 10, 64)
+	case arrow.FLOAT32, arrow.FLOAT64:
+		return strconv.ParseFloat(s, 64)
+	case arrow.STRING:
+		return s, nil
+	default:
+		return nil, errors.Errorf("arrow/ipc: invalid key type %v", dt)
+	}
+}
@@ -0,0 +1,258 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipc_test
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/ipc"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+func TestFileIndex(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema(
+		[]arrow.Field{
+			{Name: "key", Type: arrow.PrimitiveTypes.Int64},
+			{Name: "name", Type: arrow.BinaryTypes.String},
+		},
+		nil,
+	)
+
+	f, err := ioutil.TempFile("", "arrow-ipc-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	defer os.Remove(f.Name())
+
+	w, err := ipc.NewFileWriter(f, ipc.WithSchema(schema), ipc.WithAllocator(mem), ipc.WithBatchStatistics("key", "name"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b := array.NewRecordBuilder(mem, schema)
+	defer b.Release()
+
+	for _, keys := range [][]int64{
+		{1, 2, 4},
+		{},
+		{5, 5, 7, 9},
+		{10, 12},
+	} {
+		names := make([]string, len(keys))
+		for i, k := range keys {
+			names[i] = string('a' + rune(k))
+		}
+		b.Field(0).(*array.Int64Builder).AppendValues(keys, nil)
+		b.Field(1).(*array.StringBuilder).AppendValues(names, nil)
+		rec := b.NewRecord()
+		err = w.Write(rec)
+		rec.Release()
+		if err != nil {
+			t.Fatalf("could not write record: %+v", err)
+		}
+	}
+
+	err = w.Close()
+	if err != nil {
+		t.Fatalf("could not close writer: %+v", err)
+	}
+
+	r, err := ipc.NewFileReader(f, ipc.WithSchema(schema), ipc.WithAllocator(mem))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	idx, err := ipc.NewFileIndex(r, "key")
+	if err != nil {
+		t.Fatalf("could not create index: %+v", err)
+	}
+
+	keys := func(recs ...array.Record) []int64 {
+		var o []int64
+		for _, rec := range recs {
+			o = append(o, rec.Column(0).(*array.Int64).Int64Values()...)
+			rec.Release()
+		}
+		return o
+	}
+
+	for _, tc := range []struct {
+		key  int64
+		want []int64
+		err  error
+	}{
+		{key: 0, err: ipc.ErrKeyNotFound},
+		{key: 1, want: []int64{1}},
+		{key: 4, want: []int64{4}},
+		{key: 5, want: []int64{5}},
+		{key: 6, err: ipc.ErrKeyNotFound},
+		{key: 9, want: []int64{9}},
+		{key: 12, want: []int64{12}},
+		{key: 13, err: ipc.ErrKeyNotFound},
+	} {
+		rec, err := idx.Lookup(tc.key)
+		if err != tc.err {
+			t.Fatalf("lookup(%d): invalid error: got=%v, want=%v", tc.key, err, tc.err)
+		}
+		if err != nil {
+			continue
+		}
+		if got := keys(rec); !reflect.DeepEqual(got, tc.want) {
+			t.Fatalf("lookup(%d): got=%v, want=%v", tc.key, got, tc.want)
+		}
+	}
+
+	for _, tc := range []struct {
+		lo, hi int64
+		want   []int64
+	}{
+		{lo: 0, hi: 1, want: nil},
+		{lo: 0, hi: 2, want: []int64{1}},
+		{lo: 2, hi: 6, want: []int64{2, 4, 5, 5}},
+		{lo: 3, hi: 5, want: []int64{4}},
+		{lo: 4, hi: 11, want: []int64{4, 5, 5, 7, 9, 10}},
+		{lo: 8, hi: 100, want: []int64{9, 10, 12}},
+		{lo: 13, hi: 100, want: nil},
+		{lo: 5, hi: 5, want: nil},
+	} {
+		recs, err := idx.LookupRange(tc.lo, tc.hi)
+		if err != nil {
+			t.Fatalf("lookup-range(%d, %d): %+v", tc.lo, tc.hi, err)
+		}
+		if got := keys(recs...); !reflect.DeepEqual(got, tc.want) {
+			t.Fatalf("lookup-range(%d, %d): got=%v, want=%v", tc.lo, tc.hi, got, tc.want)
+		}
+	}
+
+	_, err = idx.Lookup("1")
+	if err == nil {
+		t.Fatalf("expected an error for invalid key type")
+	}
+
+	sidx, err := ipc.NewFileIndex(r, "name")
+	if err != nil {
+		t.Fatalf("could not create index: %+v", err)
+	}
+	rec, err := sidx.Lookup("h")
+	if err != nil {
+		t.Fatalf("could not lookup string key: %+v", err)
+	}
+	if got, want := keys(rec), []int64{7}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("lookup(%q): got=%v, want=%v", "h", got, want)
+	}
+}
+
+func TestFileIndexNoStatistics(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema([]arrow.Field{{Name: "key", Type: arrow.PrimitiveTypes.Int64}}, nil)
+
+	f, err := ioutil.TempFile("", "arrow-ipc-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	defer os.Remove(f.Name())
+
+	w, err := ipc.NewFileWriter(f, ipc.WithSchema(schema), ipc.WithAllocator(mem))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = w.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := ipc.NewFileReader(f, ipc.WithSchema(schema), ipc.WithAllocator(mem))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	_, err = ipc.NewFileIndex(r, "key")
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+}
+
+func TestFileIndexUnsorted(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema([]arrow.Field{{Name: "key", Type: arrow.PrimitiveTypes.Int64}}, nil)
+
+	for _, tc := range []struct {
+		name string
+		keys [][]int64
+	}{
+		{
+			name: "within-batch",
+			keys: [][]int64{{5, 1, 3, 9, 2}},
+		},
+		{
+			name: "across-batches",
+			keys: [][]int64{{1, 5, 9}, {}, {8, 10}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := ioutil.TempFile("", "arrow-ipc-")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+			defer os.Remove(f.Name())
+
+			w, err := ipc.NewFileWriter(f, ipc.WithSchema(schema), ipc.WithAllocator(mem), ipc.WithBatchStatistics("key"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer w.Close()
+
+			b := array.NewRecordBuilder(mem, schema)
+			defer b.Release()
+
+			var errs []error
+			for _, keys := range tc.keys {
+				b.Field(0).(*array.Int64Builder).AppendValues(keys, nil)
+				rec := b.NewRecord()
+				errs = append(errs, w.Write(rec))
+				rec.Release()
+			}
+
+			last := len(errs) - 1
+			for i, err := range errs[:last] {
+				if err != nil {
+					t.Fatalf("could not write record %d: %+v", i, err)
+				}
+			}
+			if errs[last] == nil {
+				t.Fatalf("expected an error writing unsorted keys")
+			}
+		})
+	}
+}
@@ -65,6 +65,19 @@ type config struct {
 	footer struct {
 		offset int64
 	}
+
+	maxRows int64    // maximum number of rows per record batch (0: unlimited)
+	stats   []string // names of the fields for which to compute batch statistics
+	sort    struct {
+		budget int64  // memory budget of sort runs, in bytes
+		dir    string // directory of the temporary sort run files
+	}
+	frame struct {
+		buffered bool  // whether to write each message with a single write
+		base64   bool  // whether to write each message as a base64-encoded line
+		checksum bool  // whether to write each message in a checksummed frame
+		first    int64 // index of the first record batch of a checksummed stream
+	}
 }
 
 func newConfig(opts ...Option) *config {
@@ -104,6 +117,109 @@ func WithSchema(schema *arrow.Schema) Option {
 	}
 }
 
+// WithMaxBatchRows specifies the maximum number of rows of the record batches
+// written to a stream.
+// Records with more than n rows are split into multiple record batches.
+// If n is zero or negative, records are written as is.
+func WithMaxBatchRows(n int64) Option {
+	return func(cfg *config) {
+		cfg.maxRows = n
+	}
+}
+
+// WithMessageBuffering specifies that each IPC message of a stream should be
+// assembled in memory and written to the underlying writer with a single call
+// to Write, instead of one call per message part.
+func WithMessageBuffering() Option {
+	return func(cfg *config) {
+		cfg.frame.buffered = true
+	}
+}
+
+// WithBase64Framing specifies that each IPC message of a stream should be
+// written as a single base64-encoded, newline-terminated, line.
+// Such streams can be decoded with NewBase64FrameReader.
+//
+// WithBase64Framing implies WithMessageBuffering.
+func WithBase64Framing() Option {
+	return func(cfg *config) {
+		cfg.frame.buffered = true
+		cfg.frame.base64 = true
+	}
+}
+
+// WithChecksumFraming specifies that each IPC message of a stream should be
+// written in a frame holding a sequence number and CRC-32C checksums.
+// Such streams can be read with NewCheckedReader, which can recover from
+// corrupted frames.
+//
+// WithChecksumFraming implies WithMessageBuffering.
+func WithChecksumFraming() Option {
+	return func(cfg *config) {
+		cfg.frame.buffered = true
+		cfg.frame.checksum = true
+	}
+}
+
+// WithFirstBatchIndex specifies the index of the first record batch of a
+// stream written or read with checksummed frames.
+//
+// A sender can use it to resume the transmission of a stream after the last
+// record batch received by a CheckedReader (see CheckedReader.LastGood),
+// skipping the record batches already sent.
+// The receiver then reads the resumed stream with a CheckedReader created
+// with the same option.
+func WithFirstBatchIndex(i int64) Option {
+	return func(cfg *config) {
+		cfg.frame.first = i
+	}
+}
+
+// WithBatchStatistics specifies the names of the fields for which the
+// minimum and maximum values of each record batch should be computed and
+// stored in the footer of an Arrow file.
+// These statistics are used by FileIndex to locate rows without reading
+// the whole file.
+//
+// The values of these fields must be sorted in ascending order across the
+// whole file, and must not be null: FileWriter.Write returns an error otherwise.
+// Only integer, floating-point and string fields are supported.
+//
+// The statistics are stored in the metadata of the schema of the file footer,
+// under the "ARROW:go:stats:<name>" keys: they show up in the metadata of
+// the schema returned by FileReader.Schema.
+func WithBatchStatistics(names ...string) Option {
+	return func(cfg *config) {
+		cfg.stats = append(cfg.stats, names...)
+	}
+}
+
+// WithMemoryBudget specifies the maximum size in bytes of the records
+// accumulated in memory by a SortReader before they are sorted and spilled
+// to a temporary file.
+//
+// A run is sorted by copying its rows into new records: while sorting, a
+// SortReader holds the records of the run (up to the budget plus the size of
+// one input record) and, if the input fits in the budget, their sorted copy,
+// that is about twice the budget.
+// When runs are spilled, the sorted copy is written out one record batch at a
+// time, and merging the runs holds one record batch per merged run (at most
+// 16) plus the record batch being yielded, regardless of the budget.
+func WithMemoryBudget(n int64) Option {
+	return func(cfg *config) {
+		cfg.sort.budget = n
+	}
+}
+
+// WithTempDir specifies the directory where a SortReader creates its
+// temporary files.
+// If dir is empty, the default directory for temporary files is used.
+func WithTempDir(dir string) Option {
+	return func(cfg *config) {
+		cfg.sort.dir = dir
+	}
+}
+
 var (
 	_ arrio.Reader = (*Reader)(nil)
 	_ arrio.Writer = (*Writer)(nil)
@@ -0,0 +1,633 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipc // import "github.com/apache/arrow/go/arrow/ipc"
+
+import (
+	"container/heap"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"sync/atomic"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/internal/debug"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/pkg/errors"
+)
+
+const (
+	kSortMemoryBudget = 64 << 20 // default memory budget of a SortReader, in bytes.
+	kSortBatchRows    = 1 << 14  // default number of rows of the records yielded by a SortReader.
+	kSortMaxFanIn     = 16       // maximum number of sorted runs merged at once.
+)
+
+// SortReader is a record reader that yields the records of another record
+// reader, sorted in ascending order of a key column.
+// Null keys are sorted first, and rows with equal keys keep their input order.
+//
+// SortReader performs an external merge sort: input records are accumulated
+// up to a memory budget, sorted and spilled as runs to temporary Arrow files.
+// If all the input records fit in the memory budget, they are sorted in
+// memory and no run is spilled.
+// Otherwise, spilled runs are merged by groups of at most 16 runs into longer
+// runs, until few enough remain to be merged in a single pass while iterating
+// over the SortReader.
+// A merge holds only one record batch per run in memory, so that memory usage
+// and the number of open files are bounded regardless of the input size
+// (see WithMemoryBudget).
+type SortReader struct {
+	refCount int64
+
+	schema *arrow.Schema
+	mem    memory.Allocator
+	key    int
+	rows   int64 // number of rows of the yielded records
+
+	files []*os.File     // spilled runs
+	recs  []array.Record // in-memory run, when no run was spilled
+	heap  sortHeap       // cursors over the runs
+	bldr  *array.RecordBuilder
+	rec   array.Record
+	err   error
+}
+
+// NewSortReader returns a reader that yields the records of r, sorted in
+// ascending order of the named key column.
+//
+// NewSortReader consumes r entirely, spilling sorted runs to temporary files
+// whenever the records accumulated in memory exceed the memory budget
+// (see WithMemoryBudget and WithTempDir).
+// The size of the yielded records can be configured with WithMaxBatchRows.
+//
+// Only boolean, integer, floating-point, decimal, string, binary, fixed-size
+// binary, date, time, timestamp and duration columns are supported.
+// Only integer, floating-point and string key columns are supported.
+func NewSortReader(r array.RecordReader, key string, opts ...Option) (*SortReader, error) {
+	cfg := newConfig(opts...)
+	if cfg.sort.budget <= 0 {
+		cfg.sort.budget = kSortMemoryBudget
+	}
+	if cfg.maxRows <= 0 {
+		cfg.maxRows = kSortBatchRows
+	}
+
+	schema := r.Schema()
+	for _, f := range schema.Fields() {
+		if !isSortableType(f.Type) {
+			return nil, errors.Errorf("arrow/ipc: sort not supported for field %q (type=%v)", f.Name, f.Type)
+		}
+	}
+
+	col := schema.FieldIndex(key)
+	if col < 0 {
+		return nil, errors.Errorf("arrow/ipc: no key field %q in schema", key)
+	}
+	if dt := schema.Field(col).Type; !isKeyType(dt) {
+		return nil, errors.Errorf("arrow/ipc: invalid key field %q (type=%v)", key, dt)
+	}
+
+	sr := &SortReader{
+		refCount: 1,
+		schema:   schema,
+		mem:      cfg.alloc,
+		key:      col,
+		rows:     cfg.maxRows,
+		bldr:     array.NewRecordBuilder(cfg.alloc, schema),
+	}
+
+	err := sr.init(r, cfg)
+	if err != nil {
+		sr.Release()
+		return nil, err
+	}
+
+	return sr, nil
+}
+
+// init consumes the input records, spilling sorted runs to disk as needed,
+// and prepares the merge of these runs.
+func (r *SortReader) init(rr array.RecordReader, cfg *config) error {
+	var (
+		run  []array.Record
+		size int64
+	)
+	defer func() {
+		for _, rec := range run {
+			rec.Release()
+		}
+	}()
+
+	spill := func() error {
+		f, err := r.writeRun(cfg.sort.dir, func(w *FileWriter) error {
+			return r.sortRun(run, func(rec array.Record) error {
+				defer rec.Release()
+				return w.Write(rec)
+			})
+		})
+		if err != nil {
+			return err
+		}
+		r.files = append(r.files, f)
+
+		for _, rec := range run {
+			rec.Release()
+		}
+		run = run[:0]
+		size = 0
+		return nil
+	}
+
+	for rr.Next() {
+		rec := rr.Record()
+		if !rec.Schema().Equal(r.schema) {
+			return errInconsistentSchema
+		}
+		rec.Retain()
+		run = append(run, rec)
+		size += recordSize(rec)
+
+		if size < cfg.sort.budget {
+			continue
+		}
+
+		err := spill()
+		if err != nil {
+			return err
+		}
+	}
+
+	var err error
+	switch {
+	case len(r.files) == 0:
+		// all the records fit in the memory budget: keep them in memory.
+		err = r.sortRun(run, func(rec array.Record) error {
+			r.recs = append(r.recs, rec)
+			return nil
+		})
+	case len(run) > 0:
+		// spill the last run too, so that only one record batch per run
+		// is held in memory while merging.
+		err = spill()
+	}
+	if err != nil {
+		return err
+	}
+
+	for len(r.files) > kSortMaxFanIn {
+		err = r.mergePass(cfg.sort.dir)
+		if err != nil {
+			return err
+		}
+	}
+
+	r.heap, err = r.cursors(r.files, r.recs)
+	return err
+}
+
+// mergePass merges groups of consecutive spilled runs into new spilled runs,
+// reducing the number of runs by a factor of kSortMaxFanIn.
+func (r *SortReader) mergePass(dir string) error {
+	var runs []*os.File
+	for len(r.files) > 0 {
+		n := len(r.files)
+		if n > kSortMaxFanIn {
+			n = kSortMaxFanIn
+		}
+		if n == 1 {
+			runs = append(runs, r.files[0])
+			r.files = r.files[1:]
+			continue
+		}
+
+		f, err := r.writeRun(dir, func(w *FileWriter) error {
+			return r.merge(r.files[:n], w)
+		})
+		if err != nil {
+			r.files = append(runs, r.files...)
+			return err
+		}
+		runs = append(runs, f)
+
+		for _, f := range r.files[:n] {
+			f.Close()
+			os.Remove(f.Name())
+		}
+		r.files = r.files[n:]
+	}
+	r.files = runs
+	return nil
+}
+
+// merge merges the provided spilled runs and writes the result to w.
+func (r *SortReader) merge(files []*os.File, w *FileWriter) error {
+	h, err := r.cursors(files, nil)
+	if err != nil {
+		return err
+	}
+	defer h.close()
+
+	for len(h) > 0 {
+		rec, err := r.next(&h)
+		if err != nil {
+			return err
+		}
+		err = w.Write(rec)
+		rec.Release()
+		if err != nil {
+			return errors.Wrap(err, "arrow/ipc: could not write merged sort run")
+		}
+	}
+	return nil
+}
+
+// writeRun creates a temporary file and writes a sorted run to it, with the
+// provided function.
+// The temporary file is removed if an error occurs.
+func (r *SortReader) writeRun(dir string, write func(w *FileWriter) error) (*os.File, error) {
+	f, err := ioutil.TempFile(dir, "arrow-sort-")
+	if err != nil {
+		return nil, errors.Wrap(err, "arrow/ipc: could not create sort run file")
+	}
+
+	err = func() error {
+		w, err := NewFileWriter(f, WithSchema(r.schema), WithAllocator(r.mem))
+		if err != nil {
+			return errors.Wrap(err, "arrow/ipc: could not create sort run writer")
+		}
+
+		err = write(w)
+		if err != nil {
+			return errors.Wrap(err, "arrow/ipc: could not write sort run")
+		}
+
+		err = w.Close()
+		if err != nil {
+			return errors.Wrap(err, "arrow/ipc: could not close sort run writer")
+		}
+		return nil
+	}()
+	if err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// cursors returns a heap of cursors over the provided spilled runs,
+// followed by the provided in-memory run.
+// Exhausted runs are skipped.
+func (r *SortReader) cursors(files []*os.File, recs []array.Record) (sortHeap, error) {
+	var h sortHeap
+	for i, f := range files {
+		fr, err := NewFileReader(f, WithSchema(r.schema), WithAllocator(r.mem))
+		if err != nil {
+			h.close()
+			return nil, errors.Wrapf(err, "arrow/ipc: could not open sort run %d", i)
+		}
+		c := &sortCursor{id: i, r: fr}
+		ok, err := c.advance(r.key)
+		if err != nil || !ok {
+			fr.Close()
+		}
+		if err != nil {
+			h.close()
+			return nil, err
+		}
+		if ok {
+			h = append(h, c)
+		}
+	}
+
+	c := &sortCursor{id: len(files), recs: recs}
+	if ok, _ := c.advance(r.key); ok {
+		h = append(h, c)
+	}
+
+	heap.Init(&h)
+	return h, nil
+}
+
+// sortRun sorts the rows of the provided records and hands them over to
+// emit as records of at most r.rows rows.
+func (r *SortReader) sortRun(run []array.Record, emit func(rec array.Record) error) error {
+	type rowRef struct {
+		rec int
+		row int
+	}
+
+	var (
+		refs []rowRef
+		keys = make([]array.Interface, len(run))
+	)
+	for i, rec := range run {
+		keys[i] = rec.Column(r.key)
+		for j := 0; j < int(rec.NumRows()); j++ {
+			refs = append(refs, rowRef{rec: i, row: j})
+		}
+	}
+
+	sort.SliceStable(refs, func(i, j int) bool {
+		ri, rj := refs[i], refs[j]
+		return compareRows(keys[ri.rec], ri.row, keys[rj.rec], rj.row) < 0
+	})
+
+	for i, ref := range refs {
+		appendRow(r.bldr, run[ref.rec], ref.row)
+		if int64(i+1)%r.rows != 0 && i+1 != len(refs) {
+			continue
+		}
+		err := emit(r.bldr.NewRecord())
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Retain increases the reference count by 1.
+// Retain may be called simultaneously from multiple goroutines.
+func (r *SortReader) Retain() {
+	atomic.AddInt64(&r.refCount, 1)
+}
+
+// Release decreases the reference count by 1.
+// When the reference count goes to zero, the memory is freed and the
+// temporary files are removed.
+// Release may be called simultaneously from multiple goroutines.
+func (r *SortReader) Release() {
+	debug.Assert(atomic.LoadInt64(&r.refCount) > 0, "too many releases")
+
+	if atomic.AddInt64(&r.refCount, -1) == 0 {
+		if r.rec != nil {
+			r.rec.Release()
+			r.rec = nil
+		}
+		for _, rec := range r.recs {
+			rec.Release()
+		}
+		r.recs = nil
+		r.heap.close()
+		r.heap = nil
+		for _, f := range r.files {
+			f.Close()
+			os.Remove(f.Name())
+		}
+		r.files = nil
+		if r.bldr != nil {
+			r.bldr.Release()
+			r.bldr = nil
+		}
+	}
+}
+
+func (r *SortReader) Schema() *arrow.Schema { return r.schema }
+
+// Err returns the last error encountered while merging the sorted runs.
+func (r *SortReader) Err() error { return r.err }
+
+// Next returns whether a record was extracted from the sorted runs.
+// The returned record is valid until the next call to Next.
+func (r *SortReader) Next() bool {
+	if r.rec != nil {
+		r.rec.Release()
+		r.rec = nil
+	}
+
+	if r.err != nil || len(r.heap) == 0 {
+		return false
+	}
+
+	r.rec, r.err = r.next(&r.heap)
+	return r.err == nil
+}
+
+// next merges the next rows of the runs of the provided heap into a record
+// of at most r.rows rows.
+// Exhausted cursors are removed from the heap and closed.
+func (r *SortReader) next(h *sortHeap) (array.Record, error) {
+	for n := int64(0); n < r.rows && len(*h) > 0; n++ {
+		c := (*h)[0]
+		appendRow(r.bldr, c.rec, c.row)
+
+		ok, err := c.advance(r.key)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case ok:
+			heap.Fix(h, 0)
+		default:
+			heap.Pop(h)
+			if c.r != nil {
+				c.r.Close()
+			}
+		}
+	}
+
+	return r.bldr.NewRecord(), nil
+}
+
+// Record returns the current record.
+// Users need to call Retain on that Record to keep it valid for longer.
+func (r *SortReader) Record() array.Record { return r.rec }
+
+// sortCursor iterates over the rows of a sorted run.
+type sortCursor struct {
+	id int // index of the run, to keep the merge stable.
+
+	r    *FileReader    // spilled run
+	recs []array.Record // in-memory run
+	irec int
+
+	rec array.Record
+	key array.Interface
+	row int
+}
+
+// advance moves the cursor to the next row of the run.
+// advance returns false when the run is exhausted.
+func (c *sortCursor) advance(key int) (bool, error) {
+	c.row++
+	for c.rec == nil || c.row >= int(c.rec.NumRows()) {
+		switch {
+		case c.r != nil:
+			rec, err := c.r.Read()
+			if err == io.EOF {
+				return false, nil
+			}
+			if err != nil {
+				return false, errors.Wrapf(err, "arrow/ipc: could not read sort run %d", c.id)
+			}
+			c.rec = rec
+		default:
+			if c.irec == len(c.recs) {
+				return false, nil
+			}
+			c.rec = c.recs[c.irec]
+			c.irec++
+		}
+		c.key = c.rec.Column(key)
+		c.row = 0
+	}
+	return true, nil
+}
+
+// sortHeap is a min-heap of cursors, ordered by their current key.
+type sortHeap []*sortCursor
+
+func (h sortHeap) Len() int { return len(h) }
+func (h sortHeap) Less(i, j int) bool {
+	ci, cj := h[i], h[j]
+	if o := compareRows(ci.key, ci.row, cj.key, cj.row); o != 0 {
+		return o < 0
+	}
+	return ci.id < cj.id
+}
+func (h sortHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *sortHeap) Push(x interface{}) { *h = append(*h, x.(*sortCursor)) }
+func (h *sortHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	c := old[n-1]
+	*h = old[:n-1]
+	return c
+}
+
+// close closes the spilled runs of the cursors of the heap.
+func (h sortHeap) close() {
+	for _, c := range h {
+		if c.r != nil {
+			c.r.Close()
+		}
+	}
+}
+
+// compareRows compares the i-th key of a with the j-th key of b.
+// Null keys compare less than any other key.
+func compareRows(a array.Interface, i int, b array.Interface, j int) int {
+	an, bn := a.IsNull(i), b.IsNull(j)
+	switch {
+	case an && bn:
+		return 0
+	case an:
+		return -1
+	case bn:
+		return +1
+	}
+	return compareKeys(keyValue(a, i), keyValue(b, j))
+}
+
+// recordSize returns the size in bytes of the buffers backing the record.
+func recordSize(rec array.Record) int64 {
+	var n int64
+	for _, col := range rec.Columns() {
+		for _, buf := range col.Data().Buffers() {
+			if buf != nil {
+				n += int64(buf.Len())
+			}
+		}
+	}
+	return n
+}
+
+// isSortableType returns whether rows holding values of the provided data
+// type can be copied by appendRow.
+func isSortableType(dt arrow.DataType) bool {
+	switch dt.ID() {
+	case arrow.BOOL,
+		arrow.INT8, arrow.INT16, arrow.INT32, arrow.INT64,
+		arrow.UINT8, arrow.UINT16, arrow.UINT32, arrow.UINT64,
+		arrow.FLOAT16, arrow.FLOAT32, arrow.FLOAT64, arrow.DECIMAL,
+		arrow.STRING, arrow.BINARY, arrow.FIXED_SIZE_BINARY,
+		arrow.DATE32, arrow.DATE64, arrow.TIME32, arrow.TIME64,
+		arrow.TIMESTAMP, arrow.DURATION:
+		return true
+	}
+	return false
+}
+
+// appendRow appends the i-th row of rec to the record builder.
+func appendRow(b *array.RecordBuilder, rec array.Record, i int) {
+	for j, col := range rec.Columns() {
+		appendValue(b.Field(j), col, i)
+	}
+}
+
+func appendValue(b array.Builder, arr array.Interface, i int) {
+	if arr.IsNull(i) {
+		b.AppendNull()
+		return
+	}
+
+	switch b := b.(type) {
+	case *array.BooleanBuilder:
+		b.Append(arr.(*array.Boolean).Value(i))
+	case *array.Int8Builder:
+		b.Append(arr.(*array.Int8).Value(i))
+	case *array.Int16Builder:
+		b.Append(arr.(*array.Int16).Value(i))
+	case *array.Int32Builder:
+		b.Append(arr.(*array.Int32).Value(i))
+	case *array.Int64Builder:
+		b.Append(arr.(*array.Int64).Value(i))
+	case *array.Uint8Builder:
+		b.Append(arr.(*array.Uint8).Value(i))
+	case *array.Uint16Builder:
+		b.Append(arr.(*array.Uint16).Value(i))
+	case *array.Uint32Builder:
+		b.Append(arr.(*array.Uint32).Value(i))
+	case *array.Uint64Builder:
+		b.Append(arr.(*array.Uint64).Value(i))
+	case *array.Float16Builder:
+		b.Append(arr.(*array.Float16).Value(i))
+	case *array.Float32Builder:
+		b.Append(arr.(*array.Float32).Value(i))
+	case *array.Float64Builder:
+		b.Append(arr.(*array.Float64).Value(i))
+	case *array.Decimal128Builder:
+		b.Append(arr.(*array.Decimal128).Value(i))
+	case *array.StringBuilder:
+		b.Append(arr.(*array.String).Value(i))
+	case *array.BinaryBuilder:
+		b.Append(arr.(*array.Binary).Value(i))
+	case *array.FixedSizeBinaryBuilder:
+		b.Append(arr.(*array.FixedSizeBinary).Value(i))
+	case *array.Time32Builder:
+		b.Append(arr.(*array.Time32).Value(i))
+	case *array.Time64Builder:
+		b.Append(arr.(*array.Time64).Value(i))
+	case *array.Date32Builder:
+		b.Append(arr.(*array.Date32).Value(i))
+	case *array.Date64Builder:
+		b.Append(arr.(*array.Date64).Value(i))
+	case *array.TimestampBuilder:
+		b.Append(arr.(*array.Timestamp).Value(i))
+	case *array.DurationBuilder:
+		b.Append(arr.(*array.Duration).Value(i))
+	default:
+		panic(errors.Errorf("arrow/ipc: unsupported builder %T", b))
+	}
+}
+
+var (
+	_ array.RecordReader = (*SortReader)(nil)
+)
@@ -0,0 +1,178 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipc_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"sort"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/ipc"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+func TestSortReader(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		budget int64
+		recs   int
+	}{
+		{name: "in-memory", budget: 1 << 30, recs: 10},
+		{name: "spill", budget: 256, recs: 10},
+		{name: "multi-pass", budget: 1, recs: 300},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+			defer mem.AssertSize(t, 0)
+
+			dir, err := ioutil.TempDir("", "arrow-ipc-sort-")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+
+			schema := arrow.NewSchema(
+				[]arrow.Field{
+					{Name: "key", Type: arrow.PrimitiveTypes.Int32, Nullable: true},
+					{Name: "seq", Type: arrow.BinaryTypes.String},
+					{Name: "ts", Type: &arrow.TimestampType{Unit: arrow.Millisecond}},
+					{Name: "date", Type: arrow.FixedWidthTypes.Date32, Nullable: true},
+				},
+				nil,
+			)
+
+			type row struct {
+				key   int32
+				valid bool
+				seq   string
+				ts    arrow.Timestamp
+				date  arrow.Date32
+			}
+
+			var (
+				rnd  = rand.New(rand.NewSource(1234))
+				rows []row
+				recs []array.Record
+				bldr = array.NewRecordBuilder(mem, schema)
+			)
+			defer bldr.Release()
+
+			for i := 0; i < tc.recs; i++ {
+				for j := 0; j < 1+rnd.Intn(30); j++ {
+					r := row{
+						key:   rnd.Int31n(20),
+						valid: rnd.Intn(10) != 0,
+						seq:   fmt.Sprintf("%d-%d", i, j),
+						ts:    arrow.Timestamp(i*1000 + j),
+						date:  arrow.Date32(i),
+					}
+					if !r.valid {
+						r.key = 0
+					}
+					rows = append(rows, r)
+					bldr.Field(0).(*array.Int32Builder).AppendValues([]int32{r.key}, []bool{r.valid})
+					bldr.Field(1).(*array.StringBuilder).Append(r.seq)
+					bldr.Field(2).(*array.TimestampBuilder).Append(r.ts)
+					bldr.Field(3).(*array.Date32Builder).Append(r.date)
+				}
+				rec := bldr.NewRecord()
+				defer rec.Release()
+				recs = append(recs, rec)
+			}
+
+			sort.SliceStable(rows, func(i, j int) bool {
+				ri, rj := rows[i], rows[j]
+				if ri.valid != rj.valid {
+					return !ri.valid
+				}
+				return ri.key < rj.key
+			})
+
+			rr, err := array.NewRecordReader(schema, recs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer rr.Release()
+
+			const maxRows = 7
+			sr, err := ipc.NewSortReader(
+				rr, "key",
+				ipc.WithAllocator(mem), ipc.WithMemoryBudget(tc.budget),
+				ipc.WithTempDir(dir), ipc.WithMaxBatchRows(maxRows),
+			)
+			if err != nil {
+				t.Fatalf("could not create sort reader: %+v", err)
+			}
+
+			files, err := ioutil.ReadDir(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tc.budget < 1<<30 && len(files) == 0 {
+				t.Fatalf("expected sort runs to be spilled to disk")
+			}
+			if len(files) > 16 {
+				t.Fatalf("too many sort runs left to merge: %d", len(files))
+			}
+
+			var got []row
+			for sr.Next() {
+				rec := sr.Record()
+				if rec.NumRows() > maxRows {
+					t.Fatalf("invalid number of rows: got=%d, want<=%d", rec.NumRows(), maxRows)
+				}
+				keys := rec.Column(0).(*array.Int32)
+				seqs := rec.Column(1).(*array.String)
+				tss := rec.Column(2).(*array.Timestamp)
+				dates := rec.Column(3).(*array.Date32)
+				for i := 0; i < int(rec.NumRows()); i++ {
+					r := row{valid: keys.IsValid(i), seq: seqs.Value(i), ts: tss.Value(i), date: dates.Value(i)}
+					if r.valid {
+						r.key = keys.Value(i)
+					}
+					got = append(got, r)
+				}
+			}
+			if err := sr.Err(); err != nil {
+				t.Fatalf("could not sort records: %+v", err)
+			}
+			sr.Release()
+
+			if len(got) != len(rows) {
+				t.Fatalf("invalid number of rows: got=%d, want=%d", len(got), len(rows))
+			}
+			for i := range rows {
+				if got[i] != rows[i] {
+					t.Fatalf("invalid row %d: got=%+v, want=%+v", i, got[i], rows[i])
+				}
+			}
+
+			files, err = ioutil.ReadDir(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(files) != 0 {
+				t.Fatalf("temporary files were not removed: %d", len(files))
+			}
+		})
+	}
+}
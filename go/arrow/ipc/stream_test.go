@@ -17,12 +17,15 @@
 package ipc_test
 
 import (
+	"bytes"
 	"io"
 	"io/ioutil"
 	"os"
 	"testing"
 
+	"github.com/apache/arrow/go/arrow/array"
 	"github.com/apache/arrow/go/arrow/internal/arrdata"
+	"github.com/apache/arrow/go/arrow/ipc"
 	"github.com/apache/arrow/go/arrow/memory"
 )
 
@@ -55,3 +58,170 @@ func TestStream(t *testing.T) {
 		})
 	}
 }
+
+type countWriter struct {
+	w io.Writer
+	n int
+}
+
+func (w *countWriter) Write(p []byte) (int, error) {
+	w.n++
+	return w.w.Write(p)
+}
+
+func TestJSWriter(t *testing.T) {
+	for name, recs := range arrdata.Records {
+		t.Run(name, func(t *testing.T) {
+			mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+			defer mem.AssertSize(t, 0)
+
+			const maxRows = 2
+
+			var (
+				buf    = new(bytes.Buffer)
+				cw     = &countWriter{w: buf}
+				schema = recs[0].Schema()
+				want   = 0
+			)
+
+			w := ipc.NewJSWriter(
+				cw,
+				ipc.WithSchema(schema), ipc.WithAllocator(mem),
+				ipc.WithMaxBatchRows(maxRows), ipc.WithBase64Framing(),
+			)
+			for _, rec := range recs {
+				err := w.Write(rec)
+				if err != nil {
+					t.Fatalf("could not write record: %+v", err)
+				}
+				switch n := rec.NumRows(); n {
+				case 0:
+					want++
+				default:
+					want += int((n + maxRows - 1) / maxRows)
+				}
+			}
+			err := w.Close()
+			if err != nil {
+				t.Fatalf("could not close writer: %+v", err)
+			}
+
+			// one message per record batch, plus the schema and end-of-stream messages.
+			if got, want := cw.n, want+2; got != want {
+				t.Fatalf("invalid number of writes: got=%d, want=%d", got, want)
+			}
+
+			r, err := ipc.NewReader(ipc.NewBase64FrameReader(buf), ipc.WithSchema(schema), ipc.WithAllocator(mem))
+			if err != nil {
+				t.Fatalf("could not create reader: %+v", err)
+			}
+			defer r.Release()
+
+			for i, rec := range recs {
+				for beg := int64(0); beg == 0 || beg < rec.NumRows(); beg += maxRows {
+					if !r.Next() {
+						t.Fatalf("could not read record batch (rec=%d, row=%d): %+v", i, beg, r.Err())
+					}
+					end := beg + maxRows
+					if end > rec.NumRows() {
+						end = rec.NumRows()
+					}
+					got := r.Record()
+					if got.NumRows() != end-beg {
+						t.Fatalf("invalid number of rows (rec=%d, row=%d): got=%d, want=%d", i, beg, got.NumRows(), end-beg)
+					}
+					for j, col := range got.Columns() {
+						if !arraySliceEqual(col, rec.Column(j), beg, end) {
+							t.Fatalf("invalid column %d (rec=%d, row=%d):\ngot= %v\nwant=%v", j, i, beg, col, rec.Column(j))
+						}
+					}
+				}
+			}
+			if r.Next() {
+				t.Fatalf("unexpected record batch")
+			}
+		})
+	}
+}
+
+// arraySliceEqual reports whether got is equal to want[beg:end].
+// Fields of struct arrays are compared element-wise, as they may be longer
+// than their parent.
+func arraySliceEqual(got, want array.Interface, beg, end int64) bool {
+	n := end - beg
+	if int64(got.Len()) != n {
+		return false
+	}
+	gs, ok := got.(*array.Struct)
+	if !ok {
+		return array.ArraySliceEqual(got, 0, n, want, beg, end)
+	}
+	ws := want.(*array.Struct)
+	for i := int64(0); i < n; i++ {
+		if gs.IsValid(int(i)) != ws.IsValid(int(beg+i)) {
+			return false
+		}
+	}
+	for i := 0; i < gs.NumField(); i++ {
+		field := array.NewSlice(gs.Field(i), 0, n)
+		defer field.Release()
+		if !arraySliceEqual(field, ws.Field(i), beg, end) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestEstimateRecordSize(t *testing.T) {
+	for name, recs := range arrdata.Records {
+		t.Run(name, func(t *testing.T) {
+			for _, tc := range []struct {
+				name string
+				opts []ipc.Option
+			}{
+				{name: "default"},
+				{name: "max-rows", opts: []ipc.Option{ipc.WithMaxBatchRows(2)}},
+				{name: "checksum", opts: []ipc.Option{ipc.WithChecksumFraming()}},
+				{name: "base64", opts: []ipc.Option{ipc.WithMaxBatchRows(3), ipc.WithBase64Framing()}},
+			} {
+				t.Run(tc.name, func(t *testing.T) {
+					mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+					defer mem.AssertSize(t, 0)
+
+					opts := append([]ipc.Option{ipc.WithAllocator(mem)}, tc.opts...)
+					for i, rec := range recs {
+						var (
+							buf = new(bytes.Buffer)
+							w   = ipc.NewWriter(buf, append(opts, ipc.WithSchema(rec.Schema()))...)
+						)
+
+						// first write also writes out the schema message.
+						err := w.Write(rec)
+						if err != nil {
+							t.Fatalf("could not write record %d: %+v", i, err)
+						}
+						beg := buf.Len()
+						err = w.Write(rec)
+						if err != nil {
+							t.Fatalf("could not write record %d: %+v", i, err)
+						}
+						want := int64(buf.Len() - beg)
+
+						err = w.Close()
+						if err != nil {
+							t.Fatalf("could not close writer: %+v", err)
+						}
+
+						got, err := ipc.EstimateRecordSize(rec, opts...)
+						if err != nil {
+							t.Fatalf("could not estimate size of record %d: %+v", i, err)
+						}
+						if got != want {
+							t.Fatalf("invalid size for record %d: got=%d, want=%d", i, got, want)
+						}
+					}
+				})
+			}
+		})
+	}
+}
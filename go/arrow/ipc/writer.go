@@ -17,7 +17,8 @@
 package ipc // import "github.com/apache/arrow/go/arrow/ipc"
 
 import (
-	"fmt"
+	"bytes"
+	"encoding/base64"
 	"io"
 	"math"
 
@@ -31,20 +32,59 @@ import (
 type swriter struct {
 	w   io.Writer
 	pos int64
+
+	buf      *bytes.Buffer // if non-nil, messages are assembled in buf before being written out.
+	base64   bool          // whether to write messages as base64-encoded lines.
+	checksum bool          // whether to write messages in checksummed frames.
+	seq      uint64        // sequence number of the next checksummed frame.
+	first    uint64        // index of the first record batch of the stream.
 }
 
 func (w *swriter) start() error { return nil }
 func (w *swriter) Close() error {
-	_, err := w.Write(kEOS[:])
-	return err
+	if w.buf == nil {
+		_, err := w.Write(kEOS[:])
+		return err
+	}
+
+	w.buf.Reset()
+	w.buf.Write(kEOS[:])
+	return w.flush()
 }
 
 func (w *swriter) write(p payload) error {
-	_, err := writeIPCPayload(w, p)
+	if w.buf == nil {
+		_, err := writeIPCPayload(w, p)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	w.buf.Reset()
+	_, err := writeIPCPayload(w.buf, p)
 	if err != nil {
 		return err
 	}
-	return nil
+	return w.flush()
+}
+
+// flush writes out the message assembled in the buffer.
+func (w *swriter) flush() error {
+	msg := w.buf.Bytes()
+	if w.checksum {
+		msg = encodeChecksumFrame(w.seq, msg)
+		if w.seq == 0 {
+			// record batches follow the schema message.
+			w.seq = w.first
+		}
+		w.seq++
+	}
+	if w.base64 {
+		msg = encodeBase64Frame(msg)
+	}
+	_, err := w.Write(msg)
+	return err
 }
 
 func (w *swriter) Write(p []byte) (int, error) {
@@ -62,19 +102,48 @@ type Writer struct {
 
 	started bool
 	schema  *arrow.Schema
+	maxRows int64
 }
 
 // NewWriter returns a writer that writes records to the provided output stream.
 func NewWriter(w io.Writer, opts ...Option) *Writer {
 	cfg := newConfig(opts...)
+	pw := &swriter{
+		w:        w,
+		base64:   cfg.frame.base64,
+		checksum: cfg.frame.checksum,
+		first:    uint64(cfg.frame.first),
+	}
+	if cfg.frame.buffered {
+		pw.buf = new(bytes.Buffer)
+	}
 	return &Writer{
-		w:      w,
-		mem:    cfg.alloc,
-		pw:     &swriter{w: w},
-		schema: cfg.schema,
+		w:       w,
+		mem:     cfg.alloc,
+		pw:      pw,
+		schema:  cfg.schema,
+		maxRows: cfg.maxRows,
 	}
 }
 
+// kJSMaxBatchRows is the default maximum number of rows of the record batches
+// written by a writer created with NewJSWriter.
+const kJSMaxBatchRows = 1 << 14
+
+// NewJSWriter returns a writer that writes records to the provided output
+// stream, with a configuration tailored for consumption by Arrow JS in a browser:
+// records are split into small record batches and each IPC message is written
+// to the output stream with a single call to Write.
+//
+// Options passed to NewJSWriter take precedence over these defaults.
+func NewJSWriter(w io.Writer, opts ...Option) *Writer {
+	opts = append([]Option{
+		WithMaxBatchRows(kJSMaxBatchRows),
+		WithMessageBuffering(),
+	}, opts...)
+	return NewWriter(w, opts...)
+}
+
 func (w *Writer) Close() error {
 	if !w.started {
 		err := w.start()
@@ -109,6 +178,78 @@ func (w *Writer) Write(rec array.Record) error {
 		return errInconsistentSchema
 	}
 
+	return splitRecord(rec, w.maxRows, w.encode)
+}
+
+// splitRecord calls f with consecutive slices of at most maxRows rows of
+// the provided record, or with the record itself if it does not need to be split.
+func splitRecord(rec array.Record, maxRows int64, f func(rec array.Record) error) error {
+	n := rec.NumRows()
+	if maxRows <= 0 || n <= maxRows {
+		return f(rec)
+	}
+
+	for i := int64(0); i < n; i += maxRows {
+		j := i + maxRows
+		if j > n {
+			j = n
+		}
+		slice := rec.NewSlice(i, j)
+		err := f(slice)
+		slice.Release()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EstimateRecordSize returns the number of bytes a stream Writer configured
+// with the provided options writes for the record: the metadata of the
+// record batch messages, their padded body buffers and their framing.
+// The schema and end-of-stream messages are not included.
+// The estimate only applies to stream writers: FileWriter ignores
+// WithMaxBatchRows and the framing options.
+//
+// EstimateRecordSize encodes the record as a Writer would. Buffers of
+// sliced records may be copied while doing so (rebased offsets and unaligned
+// validity bitmaps), using the allocator of the provided options.
+// This package does not compress message bodies: the returned size is
+// always the uncompressed one.
+func EstimateRecordSize(rec array.Record, opts ...Option) (int64, error) {
+	var (
+		cfg  = newConfig(opts...)
+		size int64
+	)
+
+	err := splitRecord(rec, cfg.maxRows, func(rec array.Record) error {
+		const allow64b = true
+		var (
+			data = payload{msg: MessageRecordBatch}
+			enc  = newRecordEncoder(cfg.alloc, 0, kMaxNestingDepth, allow64b)
+		)
+		defer data.Release()
+
+		if err := enc.Encode(&data, rec); err != nil {
+			return errors.Wrap(err, "arrow/ipc: could not encode record to payload")
+		}
+
+		// continuation indicator and metadata size prefix, metadata, padding and body.
+		n := paddedLength(int64(data.meta.Len())+8, kArrowIPCAlignment) + data.size
+		if cfg.frame.checksum {
+			n += kFrameHeaderSize + kFrameTrailerSize
+		}
+		if cfg.frame.base64 {
+			n = int64(base64.StdEncoding.EncodedLen(int(n))) + 1
+		}
+		size += n
+		return nil
+	})
+
+	return size, err
+}
+
+func (w *Writer) encode(rec array.Record) error {
 	const allow64b = true
 	var (
 		data = payload{msg: MessageRecordBatch}
@@ -249,59 +390,20 @@ func (w *recordEncoder) visit(p *payload, arr array.Interface) error {
 	case arrow.FixedWidthDataType:
 		data := arr.Data()
 		values := data.Buffers()[1]
-		arrLen := int64(arr.Len())
-		typeWidth := int64(dtype.BitWidth() / 8)
-		minLength := paddedLength(arrLen*typeWidth, kArrowAlignment)
-
-		switch {
-		case needTruncate(int64(data.Offset()), values, minLength):
-			// non-zero offset: slice the buffer
-			offset := int64(data.Offset()) * typeWidth
-			// send padding if available
-			len := minI64(bitutil.CeilByte64(arrLen*typeWidth), int64(data.Len())-offset)
-			data = array.NewSliceData(data, offset, offset+len)
-			defer data.Release()
-			values = data.Buffers()[1]
-		}
 		if values != nil {
-			values.Retain()
-		}
-		p.body = append(p.body, values)
-
-	case *arrow.BinaryType:
-		arr := arr.(*array.Binary)
-		voffsets, err := w.getZeroBasedValueOffsets(arr)
-		if err != nil {
-			return errors.Wrapf(err, "could not retrieve zero-based value offsets from %T", arr)
-		}
-		data := arr.Data()
-		values := data.Buffers()[2]
-
-		var totalDataBytes int64
-		if voffsets != nil {
-			totalDataBytes = int64(len(arr.ValueBytes()))
-		}
-
-		switch {
-		case needTruncate(int64(data.Offset()), values, totalDataBytes):
-			// slice data buffer to include the range we need now.
-			var (
-				beg = int64(arr.ValueOffset(0))
-				len = minI64(paddedLength(totalDataBytes, kArrowAlignment), int64(data.Len())-beg)
-			)
-			data = array.NewSliceData(data, beg, beg+len)
-			defer data.Release()
-			values = data.Buffers()[2]
-		default:
-			if values != nil {
-				values.Retain()
+			// with a sliced array / non-zero offset, only send the range we need.
+			typeWidth := int64(dtype.BitWidth() / 8)
+			if _, ok := dtype.(*arrow.Decimal128Type); ok {
+				// Decimal128Type.BitWidth reports its width in bytes.
+				typeWidth = int64(arrow.Decimal128SizeBytes)
 			}
+			beg := int64(data.Offset()) * typeWidth
+			end := beg + int64(data.Len())*typeWidth
+			values = newTruncatedBuffer(values, beg, end)
 		}
-		p.body = append(p.body, voffsets)
 		p.body = append(p.body, values)
 
-	case *arrow.StringType:
-		arr := arr.(*array.String)
+	case *arrow.BinaryType, *arrow.StringType:
 		voffsets, err := w.getZeroBasedValueOffsets(arr)
 		if err != nil {
 			return errors.Wrapf(err, "could not retrieve zero-based value offsets from %T", arr)
@@ -309,25 +411,13 @@ func (w *recordEncoder) visit(p *payload, arr array.Interface) error {
 		data := arr.Data()
 		values := data.Buffers()[2]
 
-		var totalDataBytes int64
-		if voffsets != nil {
-			totalDataBytes = int64(arr.ValueOffset(arr.Len()) - arr.ValueOffset(0))
-		}
-
 		switch {
-		case needTruncate(int64(data.Offset()), values, totalDataBytes):
+		case voffsets != nil && values != nil:
 			// slice data buffer to include the range we need now.
-			var (
-				beg = int64(arr.ValueOffset(0))
-				len = minI64(paddedLength(totalDataBytes, kArrowAlignment), int64(data.Len())-beg)
-			)
-			data = array.NewSliceData(data, beg, beg+len)
-			defer data.Release()
-			values = data.Buffers()[2]
-		default:
-			if values != nil {
-				values.Retain()
-			}
+			beg, end := valueOffsetsRange(data)
+			values = newTruncatedBuffer(values, beg, end)
+		case values != nil:
+			values.Retain()
 		}
 		p.body = append(p.body, voffsets)
 		p.body = append(p.body, values)
@@ -336,7 +426,14 @@ func (w *recordEncoder) visit(p *payload, arr array.Interface) error {
 		w.depth--
 		arr := arr.(*array.Struct)
 		for i := 0; i < arr.NumField(); i++ {
-			err := w.visit(p, arr.Field(i))
+			field := arr.Field(i)
+			if off := int64(arr.Offset()); off != 0 {
+				// the validity bitmap of a sliced struct-array has been
+				// rebased to zero: the fields must be sliced as well.
+				field = array.NewSlice(field, off, off+int64(arr.Len()))
+				defer field.Release()
+			}
+			err := w.visit(p, field)
 			if err != nil {
 				return errors.Wrapf(err, "could not visit field %d of struct-array", i)
 			}
@@ -365,13 +462,14 @@ func (w *recordEncoder) visit(p *payload, arr array.Interface) error {
 		}()
 
 		if voffsets != nil {
-			values_offset = int64(arr.Offsets()[0])
-			values_length = int64(arr.Offsets()[arr.Len()]) - values_offset
+			beg, end := valueOffsetsRange(arr.Data())
+			values_offset = beg
+			values_length = end - beg
 		}
 
-		if len(arr.Offsets()) != 0 || values_length < int64(values.Len()) {
+		if values_offset != 0 || values_length < int64(values.Len()) {
 			// must also slice the values
-			values = array.NewSlice(values, values_offset, values_length)
+			values = array.NewSlice(values, values_offset, values_offset+values_length)
 			mustRelease = true
 		}
 		err = w.visit(p, values)
@@ -410,16 +508,36 @@ func (w *recordEncoder) visit(p *payload, arr array.Interface) error {
 func (w *recordEncoder) getZeroBasedValueOffsets(arr array.Interface) (*memory.Buffer, error) {
 	data := arr.Data()
 	voffsets := data.Buffers()[1]
-	if data.Offset() != 0 {
-		// FIXME(sbinet): writer.cc:231
-		panic(fmt.Errorf("not implemented offset=%d", data.Offset()))
-	}
 	if voffsets == nil || voffsets.Len() == 0 {
 		return nil, nil
 	}
 
-	voffsets.Retain()
-	return voffsets, nil
+	var (
+		beg     = data.Offset()
+		end     = beg + data.Len() + 1
+		offsets = arrow.Int32Traits.CastFromBytes(voffsets.Bytes())[beg:end]
+		nbytes  = arrow.Int32Traits.BytesRequired(len(offsets))
+	)
+
+	if offsets[0] == 0 {
+		return newTruncatedBuffer(voffsets, int64(beg*arrow.Int32SizeBytes), int64(beg*arrow.Int32SizeBytes+nbytes)), nil
+	}
+
+	// with a sliced array / non-zero offset, we must rebase the offsets.
+	buf := memory.NewResizableBuffer(w.mem)
+	buf.Resize(nbytes)
+	shifted := arrow.Int32Traits.CastFromBytes(buf.Bytes())
+	for i, o := range offsets {
+		shifted[i] = o - offsets[0]
+	}
+	return buf, nil
+}
+
+// valueOffsetsRange returns the range of the values referenced by the
+// value offsets of the provided variable-length data.
+func valueOffsetsRange(data *array.Data) (beg, end int64) {
+	offsets := arrow.Int32Traits.CastFromBytes(data.Buffers()[1].Bytes())
+	return int64(offsets[data.Offset()]), int64(offsets[data.Offset()+data.Len()])
 }
 
 func (w *recordEncoder) encodeMetadata(p *payload, nrows int64) error {
@@ -428,32 +546,37 @@ func (w *recordEncoder) encodeMetadata(p *payload, nrows int64) error {
 }
 
 func newTruncatedBitmap(mem memory.Allocator, offset, length int64, input *memory.Buffer) *memory.Buffer {
-	if input != nil {
-		input.Retain()
-		return input
+	if input == nil {
+		return nil
 	}
 
-	minLength := paddedLength(bitutil.BytesForBits(length), kArrowAlignment)
-	switch {
-	case offset != 0 || minLength < int64(input.Len()):
-		// with a sliced array / non-zero offset, we must copy the bitmap
-		panic("not implemented") // FIXME(sbinet): writer.cc:75
-	default:
-		input.Retain()
-		return input
+	nbytes := bitutil.BytesForBits(length)
+	if offset%8 == 0 {
+		return newTruncatedBuffer(input, offset/8, offset/8+nbytes)
 	}
-}
 
-func needTruncate(offset int64, buf *memory.Buffer, minLength int64) bool {
-	if buf == nil {
-		return false
+	// with a sliced array / non-zero offset, we must copy the bitmap
+	buf := memory.NewResizableBuffer(mem)
+	buf.Resize(int(nbytes))
+	var (
+		src = input.Bytes()
+		dst = buf.Bytes()
+	)
+	memory.Set(dst, 0)
+	for i := 0; i < int(length); i++ {
+		if bitutil.BitIsSet(src, int(offset)+i) {
+			bitutil.SetBit(dst, i)
+		}
 	}
-	return offset != 0 || minLength < int64(buf.Len())
+	return buf
 }
 
-func minI64(a, b int64) int64 {
-	if a < b {
-		return a
+// newTruncatedBuffer returns a buffer holding the [beg:end] range of the input buffer.
+// The input buffer is retained if the range spans it entirely.
+func newTruncatedBuffer(input *memory.Buffer, beg, end int64) *memory.Buffer {
+	if beg == 0 && end == int64(input.Len()) {
+		input.Retain()
+		return input
 	}
-	return b
+	return memory.NewBufferBytes(input.Bytes()[beg:end])
 }